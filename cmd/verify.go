@@ -18,15 +18,23 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 
 	"github.com/redhat-certification/chart-verifier/pkg/chartverifier"
+	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
 )
 
+// rulesConfigKey is the config key holding the rule files declaring custom checks, along with the --rules ones.
+const rulesConfigKey = "rules"
+
 func init() {
 	allChecks = chartverifier.DefaultRegistry().AllChecks()
 }
@@ -41,6 +49,12 @@ var (
 	disabledChecksFlag []string
 	// outputFormatFlag contains the output format the user has specified: default, yaml or json.
 	outputFormatFlag string
+	// failOnFlag contains the lowest severity of the failed checks failing the verification.
+	failOnFlag string
+	// profileFlag contains the profile, optionally followed by '@' and its version, the chart is verified against.
+	profileFlag string
+	// rulesFlag contains the rule files declaring custom checks.
+	rulesFlag []string
 )
 
 func filterChecks(set []string, subset []string, setEnabled bool, subsetEnabled bool) ([]string, error) {
@@ -76,9 +90,75 @@ func buildChecks(all, enabled, disabled []string) ([]string, error) {
 	}
 }
 
-func buildCertifier(checks []string) (chartverifier.Certifier, error) {
+// checksHelp describes the checks available in the given registry, grouped by category.
+func checksHelp(registry checks.Registry) string {
+	byCategory := map[checks.CheckCategory][]checks.Check{}
+	for _, name := range registry.AllChecks() {
+		check, _ := registry.Get(name)
+		byCategory[check.Category] = append(byCategory[check.Category], check)
+	}
+
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, string(category))
+	}
+	sort.Strings(categories)
+
+	var b strings.Builder
+	b.WriteString("Available checks:\n")
+	for _, category := range categories {
+		b.WriteString("\n  " + category + ":\n")
+		checkList := byCategory[checks.CheckCategory(category)]
+		sort.Slice(checkList, func(i, j int) bool { return checkList[i].Name < checkList[j].Name })
+		for _, check := range checkList {
+			severity := check.Severity
+			if severity == "" {
+				severity = checks.ErrorSeverity
+			}
+			b.WriteString("    " + check.Name + " (" + check.Version + ", " + string(severity) + "): " +
+				check.Description + "\n")
+		}
+	}
+	return b.String()
+}
+
+// profilesHelp describes the given profiles.
+func profilesHelp(profiles []chartverifier.Profile) string {
+	var b strings.Builder
+	b.WriteString("Available profiles:\n\n")
+	for _, p := range profiles {
+		mandatory := 0
+		for _, c := range p.Checks {
+			if c.Mandatory {
+				mandatory++
+			}
+		}
+		b.WriteString(fmt.Sprintf("  %s@%s: %d mandatory and %d advisory checks\n",
+			p.Name, p.Version, mandatory, len(p.Checks)-mandatory))
+	}
+	return b.String()
+}
+
+// buildRegistry returns the default registry along with the custom checks declared in the given rule files.
+func buildRegistry(ruleFiles []string) (checks.Registry, error) {
+	if len(ruleFiles) == 0 {
+		return chartverifier.DefaultRegistry(), nil
+	}
+	return chartverifier.RegistryWithRules(chartverifier.DefaultRegistry(), ruleFiles)
+}
+
+func buildCertifier(
+	registry checks.Registry,
+	checkNames []string,
+	failOn checks.Severity,
+	profile *chartverifier.Profile,
+) (chartverifier.Certifier, error) {
 	return chartverifier.NewCertifierBuilder().
-		SetChecks(checks).
+		SetRegistry(registry).
+		SetChecks(checkNames).
+		SetConfig(viper.GetViper()).
+		SetFailOn(failOn).
+		SetProfile(profile).
 		Build()
 }
 
@@ -87,13 +167,44 @@ func NewVerifyCmd() *cobra.Command {
 		Use:   "verify <chart-uri>",
 		Args:  cobra.ExactArgs(1),
 		Short: "Verifies a Helm chart by checking some of its characteristics",
+		Long: "Verifies a Helm chart by checking some of its characteristics.\n\n" +
+			checksHelp(chartverifier.DefaultRegistry()) + "\n" +
+			profilesHelp(chartverifier.DefaultProfiles()),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checks, err := buildChecks(allChecks, enabledChecksFlag, disabledChecksFlag)
+			ruleFiles := append(append([]string{}, rulesFlag...), viper.GetStringSlice(rulesConfigKey)...)
+			registry, err := buildRegistry(ruleFiles)
 			if err != nil {
 				return err
 			}
 
-			certifier, err := buildCertifier(checks)
+			var profile *chartverifier.Profile
+			available := registry.AllChecks()
+			if profileFlag != "" {
+				p, err := chartverifier.GetProfile(profileFlag)
+				if err != nil {
+					return err
+				}
+				profile = p
+				// the custom checks are run along with the profile ones
+				available = p.CheckNames()
+				for _, name := range registry.AllChecks() {
+					if _, ok := chartverifier.DefaultRegistry().Get(name); !ok {
+						available = append(available, name)
+					}
+				}
+			}
+
+			checkNames, err := buildChecks(available, enabledChecksFlag, disabledChecksFlag)
+			if err != nil {
+				return err
+			}
+
+			failOn, err := checks.ParseSeverity(failOnFlag)
+			if err != nil {
+				return err
+			}
+
+			certifier, err := buildCertifier(registry, checkNames, failOn, profile)
 			if err != nil {
 				return err
 			}
@@ -132,6 +243,16 @@ func NewVerifyCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&outputFormatFlag, "output", "o", "", "the output format: default, json or yaml")
 
+	cmd.Flags().StringVar(&failOnFlag, "fail-on", string(checks.ErrorSeverity),
+		"the lowest severity of the failed checks failing the verification: info, warning or error")
+
+	cmd.Flags().StringVarP(&profileFlag, "profile", "p", "",
+		"the profile to verify against, optionally followed by @ and its version, such as partner@1.0; "+
+			"--enable and --disable then select among its checks")
+
+	cmd.Flags().StringSliceVar(&rulesFlag, "rules", nil,
+		"rule files declaring custom checks, run along with the other checks")
+
 	return cmd
 }
 
@@ -114,7 +114,10 @@ func TestCertify(t *testing.T) {
 
 		expected := "chart: chart\n" +
 			"version: 1.16.0\n" +
+			"classification: community\n" +
+			"supported-openshift-versions: 4.7\n" +
 			"ok: true\n" +
+			"fail-on: error\n" +
 			"\n" +
 			"is-helm-v3:\n" +
 			"\tok: true\n" +
@@ -122,6 +125,223 @@ func TestCertify(t *testing.T) {
 		require.Equal(t, expected, outBuf.String())
 	})
 
+	t.Run("Should tell how to fix the chart when a check fails", func(t *testing.T) {
+		cmd := NewVerifyCmd()
+		outBuf := bytes.NewBufferString("")
+		cmd.SetOut(outBuf)
+		errBuf := bytes.NewBufferString("")
+		cmd.SetErr(errBuf)
+
+		cmd.SetArgs([]string{
+			"-e", "has-readme",
+			"../pkg/chartverifier/checks/chart-0.1.0-v3.without-readme.tgz",
+		})
+		require.NoError(t, cmd.Execute())
+
+		expected := "has-readme:\n" +
+			"\tok: false\n" +
+			"\treason: " + checks.ReadmeDoesNotExist + "\n" +
+			"\tseverity: error\n" +
+			"\tremediation: Add a README.md file describing the chart and its values\n" +
+			"\tdocs: https://github.com/redhat-certification/chart-verifier#checks\n"
+		require.Contains(t, outBuf.String(), expected)
+	})
+
+	t.Run("Should only fail on failed checks at or above the --fail-on severity", func(t *testing.T) {
+		for _, tc := range []struct {
+			failOn string
+			ok     bool
+		}{
+			{failOn: "", ok: true},
+			{failOn: "error", ok: true},
+			{failOn: "warning", ok: false},
+			{failOn: "info", ok: false},
+		} {
+			cmd := NewVerifyCmd()
+			outBuf := bytes.NewBufferString("")
+			cmd.SetOut(outBuf)
+			errBuf := bytes.NewBufferString("")
+			cmd.SetErr(errBuf)
+
+			// images-are-pinned-by-digest is a warning, and the valid chart's images are pinned by tag
+			args := []string{"-e", "images-are-pinned-by-digest", "-o", "json"}
+			if tc.failOn != "" {
+				args = append(args, "--fail-on", tc.failOn)
+			}
+			cmd.SetArgs(append(args, "../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz"))
+			require.NoError(t, cmd.Execute())
+
+			actual := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(outBuf.Bytes(), &actual))
+			require.Equal(t, tc.ok, actual["ok"], tc.failOn)
+			require.Equal(t, "warning",
+				actual["results"].(map[string]interface{})["images-are-pinned-by-digest"].(map[string]interface{})["severity"])
+		}
+	})
+
+	t.Run("Should not fail on the classification checks by default", func(t *testing.T) {
+		for _, failOn := range []string{"error", "warning"} {
+			cmd := NewVerifyCmd()
+			outBuf := bytes.NewBufferString("")
+			cmd.SetOut(outBuf)
+			errBuf := bytes.NewBufferString("")
+			cmd.SetErr(errBuf)
+
+			cmd.SetArgs([]string{
+				"-e", "is-commercial-chart,is-community-chart",
+				"--fail-on", failOn,
+				"-o", "json",
+				"../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz",
+			})
+			require.NoError(t, cmd.Execute())
+
+			actual := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(outBuf.Bytes(), &actual))
+			require.Equal(t, true, actual["ok"], failOn)
+
+			// the checks are mutually exclusive, the one not matching the classification fails
+			results := actual["results"].(map[string]interface{})
+			require.Equal(t, false, results["is-commercial-chart"].(map[string]interface{})["ok"])
+			require.Equal(t, true, results["is-community-chart"].(map[string]interface{})["ok"])
+			for _, r := range results {
+				require.Equal(t, "info", r.(map[string]interface{})["severity"])
+			}
+		}
+	})
+
+	t.Run("Should succeed for the valid chart with the default checks", func(t *testing.T) {
+		cmd := NewVerifyCmd()
+		outBuf := bytes.NewBufferString("")
+		cmd.SetOut(outBuf)
+		errBuf := bytes.NewBufferString("")
+		cmd.SetErr(errBuf)
+
+		cmd.SetArgs([]string{
+			"-o", "json",
+			"../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz",
+		})
+		require.NoError(t, cmd.Execute())
+
+		actual := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(outBuf.Bytes(), &actual))
+		require.Equal(t, true, actual["ok"])
+
+		// incomplete metadata is only a warning outside of the profiles
+		metadata := actual["results"].(map[string]interface{})["has-complete-metadata"].(map[string]interface{})
+		require.Equal(t, false, metadata["ok"])
+		require.Equal(t, "warning", metadata["severity"])
+	})
+
+	t.Run("Should record the profile and its check severities when --profile is given", func(t *testing.T) {
+		cmd := NewVerifyCmd()
+		outBuf := bytes.NewBufferString("")
+		cmd.SetOut(outBuf)
+		errBuf := bytes.NewBufferString("")
+		cmd.SetErr(errBuf)
+
+		cmd.SetArgs([]string{
+			"--profile", "community@1.0",
+			"-e", "is-helm-v3,images-are-pinned-by-digest",
+			"-o", "json",
+			"../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz",
+		})
+		require.NoError(t, cmd.Execute())
+
+		actual := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(outBuf.Bytes(), &actual))
+		require.Equal(t, map[string]interface{}{"name": "community", "version": "1.0"},
+			actual["metadata"].(map[string]interface{})["profile"])
+		results := actual["results"].(map[string]interface{})
+		require.Equal(t, "error", results["is-helm-v3"].(map[string]interface{})["severity"])
+		require.Equal(t, "warning", results["images-are-pinned-by-digest"].(map[string]interface{})["severity"])
+		require.Equal(t, true, actual["ok"])
+	})
+
+	t.Run("Should fail when --profile is unknown", func(t *testing.T) {
+		cmd := NewVerifyCmd()
+		outBuf := bytes.NewBufferString("")
+		cmd.SetOut(outBuf)
+		errBuf := bytes.NewBufferString("")
+		cmd.SetErr(errBuf)
+
+		cmd.SetArgs([]string{
+			"--profile", "partner@0.1",
+			"../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz",
+		})
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("Should fail when an enabled check is not part of the profile", func(t *testing.T) {
+		cmd := NewVerifyCmd()
+		outBuf := bytes.NewBufferString("")
+		cmd.SetOut(outBuf)
+		errBuf := bytes.NewBufferString("")
+		cmd.SetErr(errBuf)
+
+		cmd.SetArgs([]string{
+			"--profile", "community",
+			"-e", "is-commercial-chart",
+			"../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz",
+		})
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("Should fail when --fail-on is not a severity", func(t *testing.T) {
+		cmd := NewVerifyCmd()
+		outBuf := bytes.NewBufferString("")
+		cmd.SetOut(outBuf)
+		errBuf := bytes.NewBufferString("")
+		cmd.SetErr(errBuf)
+
+		cmd.SetArgs([]string{
+			"-e", "is-helm-v3",
+			"--fail-on", "fatal",
+			"../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz",
+		})
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("Should run the custom checks declared in the --rules files", func(t *testing.T) {
+		cmd := NewVerifyCmd()
+		outBuf := bytes.NewBufferString("")
+		cmd.SetOut(outBuf)
+		errBuf := bytes.NewBufferString("")
+		cmd.SetErr(errBuf)
+
+		cmd.SetArgs([]string{
+			"--rules", "../pkg/chartverifier/checks/rules.yaml",
+			"--profile", "community",
+			"-e", "is-helm-v3,deployments-have-team-label",
+			"-o", "json",
+			"../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz",
+		})
+		require.NoError(t, cmd.Execute())
+
+		actual := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(outBuf.Bytes(), &actual))
+		require.Equal(t, false, actual["ok"])
+		result := actual["results"].(map[string]interface{})["deployments-have-team-label"].(map[string]interface{})
+		require.Equal(t, "Deployments do not have the team label", result["reason"])
+		require.Equal(t, "custom", result["category"])
+		require.Equal(t, "error", result["severity"])
+	})
+
+	t.Run("Should fail when a --rules file is invalid", func(t *testing.T) {
+		cmd := NewVerifyCmd()
+		outBuf := bytes.NewBufferString("")
+		cmd.SetOut(outBuf)
+		errBuf := bytes.NewBufferString("")
+		cmd.SetErr(errBuf)
+
+		cmd.SetArgs([]string{
+			"--rules", "../pkg/chartverifier/checks/rules-invalid.yaml",
+			"../pkg/chartverifier/checks/chart-0.1.0-v3.valid.tgz",
+		})
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `unknown target source "readme"`)
+	})
+
 	t.Run("Should display JSON certificate when option --output and argument values are given", func(t *testing.T) {
 		cmd := NewVerifyCmd()
 		outBuf := bytes.NewBufferString("")
@@ -145,17 +365,25 @@ func TestCertify(t *testing.T) {
 		expected := map[string]interface{}{
 			"metadata": map[string]interface{}{
 				"chart": map[string]interface{}{
-					"name":    "chart",
-					"version": "1.16.0",
+					"name":                       "chart",
+					"version":                    "1.16.0",
+					"classification":             "community",
+					"supportedOpenshiftVersions": []interface{}{"4.7"},
 				},
 			},
 			"ok": true,
 			"results": map[string]interface{}{
 				"is-helm-v3": map[string]interface{}{
-					"ok":     true,
-					"reason": checks.Helm3Reason,
+					"ok":          true,
+					"reason":      checks.Helm3Reason,
+					"description": "Checks whether the chart is a Helm v3 chart",
+					"category":    "structure",
+					"version":     "1.0",
+					"docs":        "https://github.com/redhat-certification/chart-verifier#checks",
+					"severity":    "error",
 				},
 			},
+			"failOn": "error",
 		}
 		require.Equal(t, expected, actual)
 	})
@@ -183,22 +411,41 @@ func TestCertify(t *testing.T) {
 		expected := map[string]interface{}{
 			"metadata": map[string]interface{}{
 				"chart": map[string]interface{}{
-					"name":    "chart",
-					"version": "1.16.0",
+					"name":                       "chart",
+					"version":                    "1.16.0",
+					"classification":             "community",
+					"supportedOpenshiftVersions": []interface{}{"4.7"},
 				},
 			},
 			"ok": true,
 			"results": map[string]interface{}{
 				"is-helm-v3": map[string]interface{}{
-					"ok":     true,
-					"reason": checks.Helm3Reason,
+					"ok":          true,
+					"reason":      checks.Helm3Reason,
+					"description": "Checks whether the chart is a Helm v3 chart",
+					"category":    "structure",
+					"version":     "1.0",
+					"docs":        "https://github.com/redhat-certification/chart-verifier#checks",
+					"severity":    "error",
 				},
 			},
+			"failOn": "error",
 		}
 		require.Equal(t, expected, actual)
 	})
 }
 
+func TestVerifyHelp(t *testing.T) {
+	cmd := NewVerifyCmd()
+	outBuf := bytes.NewBufferString("")
+	cmd.SetOut(outBuf)
+	cmd.SetArgs([]string{"--help"})
+	require.NoError(t, cmd.Execute())
+
+	require.Contains(t, outBuf.String(), "\n  structure:\n")
+	require.Contains(t, outBuf.String(), "    is-helm-v3 (1.0, error): Checks whether the chart is a Helm v3 chart\n")
+}
+
 func TestBuildChecks(t *testing.T) {
 	t.Run("Should fail when enabledChecks and disabledChecks have more than one item at the same time", func(t *testing.T) {
 		var (
@@ -16,28 +16,47 @@
 
 package chartverifier
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+
+	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+)
 
 type chartMetadata struct {
+	Name           string `json:"name" yaml:"name"`
+	Version        string `json:"version" yaml:"version"`
+	Classification string `json:"classification,omitempty" yaml:"classification,omitempty"`
+	// SupportedOpenshiftVersions are the OpenShift releases satisfying the chart's kubeVersion.
+	SupportedOpenshiftVersions []string `json:"supportedOpenshiftVersions,omitempty" yaml:"supportedOpenshiftVersions,omitempty"`
+}
+
+// profileMetadata identifies the profile the chart has been verified against.
+type profileMetadata struct {
 	Name    string `json:"name" yaml:"name"`
 	Version string `json:"version" yaml:"version"`
 }
 
 type metadata struct {
-	ChartMetadata chartMetadata `json:"chart" yaml:"chart"`
+	ChartMetadata chartMetadata    `json:"chart" yaml:"chart"`
+	Profile       *profileMetadata `json:"profile,omitempty" yaml:"profile,omitempty"`
 }
 
-func newMetadata(name, version string) *metadata {
+func newMetadata(name, version, classification string, openshiftVersions []string) *metadata {
 	return &metadata{
 		ChartMetadata: chartMetadata{
-			Name:    name,
-			Version: version,
+			Name:                       name,
+			Version:                    version,
+			Classification:             classification,
+			SupportedOpenshiftVersions: openshiftVersions,
 		},
 	}
 }
 
 type certificate struct {
-	Ok             bool           `json:"ok" yaml:"ok"`
+	Ok bool `json:"ok" yaml:"ok"`
+	// FailOn is the lowest severity of the failed checks failing the certificate.
+	FailOn         string         `json:"failOn" yaml:"failOn"`
 	Metadata       *metadata      `json:"metadata" yaml:"metadata"`
 	CheckResultMap checkResultMap `json:"results" yaml:"results"`
 }
@@ -45,14 +64,25 @@ type certificate struct {
 type checkResultMap map[string]checkResult
 
 type checkResult struct {
-	Ok     bool   `json:"ok" yaml:"ok"`
-	Reason string `json:"reason" yaml:"reason"`
+	Ok          bool     `json:"ok" yaml:"ok"`
+	Reason      string   `json:"reason" yaml:"reason"`
+	Details     []string `json:"details,omitempty" yaml:"details,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Category    string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Version     string   `json:"version,omitempty" yaml:"version,omitempty"`
+	DocsURL     string   `json:"docs,omitempty" yaml:"docs,omitempty"`
+	Severity    string   `json:"severity" yaml:"severity"`
+	// Remediation tells how to fix the chart, only when the check has failed.
+	Remediation string `json:"remediation,omitempty" yaml:"remediation,omitempty"`
 }
 
-func newCertificate(name, version string, ok bool, resultMap checkResultMap) Certificate {
+func newCertificate(
+	name, version, classification string, openshiftVersions []string, failOn checks.Severity, ok bool,
+	resultMap checkResultMap) *certificate {
 	return &certificate{
-		Metadata:       newMetadata(name, version),
+		Metadata:       newMetadata(name, version, classification, openshiftVersions),
 		Ok:             ok,
+		FailOn:         string(failOn),
 		CheckResultMap: resultMap,
 	}
 }
@@ -63,14 +93,44 @@ func (c *certificate) IsOk() bool {
 
 func (c *certificate) String() string {
 	report := "chart: " + c.Metadata.ChartMetadata.Name + "\n" +
-		"version: " + c.Metadata.ChartMetadata.Version + "\n" +
-		"ok: " + strconv.FormatBool(c.Ok) + "\n" +
+		"version: " + c.Metadata.ChartMetadata.Version + "\n"
+
+	if c.Metadata.ChartMetadata.Classification != "" {
+		report += "classification: " + c.Metadata.ChartMetadata.Classification + "\n"
+	}
+
+	if len(c.Metadata.ChartMetadata.SupportedOpenshiftVersions) > 0 {
+		report += "supported-openshift-versions: " +
+			strings.Join(c.Metadata.ChartMetadata.SupportedOpenshiftVersions, ", ") + "\n"
+	}
+
+	if p := c.Metadata.Profile; p != nil {
+		report += "profile: " + p.Name + "@" + p.Version + "\n"
+	}
+
+	report += "ok: " + strconv.FormatBool(c.Ok) + "\n" +
+		"fail-on: " + c.FailOn + "\n" +
 		"\n"
 
 	for k, v := range c.CheckResultMap {
 		report += k + ":\n" +
 			"\tok: " + strconv.FormatBool(v.Ok) + "\n" +
 			"\treason: " + v.Reason + "\n"
+		if !v.Ok {
+			report += "\tseverity: " + v.Severity + "\n"
+		}
+		if len(v.Details) > 0 {
+			report += "\tdetails:\n"
+			for _, d := range v.Details {
+				report += "\t\t- " + d + "\n"
+			}
+		}
+		if v.Remediation != "" {
+			report += "\tremediation: " + v.Remediation + "\n"
+		}
+		if !v.Ok && v.DocsURL != "" {
+			report += "\tdocs: " + v.DocsURL + "\n"
+		}
 	}
 
 	return report
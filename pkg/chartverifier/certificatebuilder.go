@@ -25,7 +25,11 @@ import (
 type CertificateBuilder interface {
 	SetChartName(name string) CertificateBuilder
 	SetChartVersion(version string) CertificateBuilder
-	AddCheckResult(name string, result checks.Result) CertificateBuilder
+	SetChartClassification(classification string) CertificateBuilder
+	SetSupportedOpenshiftVersions(versions []string) CertificateBuilder
+	SetFailOn(severity checks.Severity) CertificateBuilder
+	SetProfile(name, version string) CertificateBuilder
+	AddCheckResult(check checks.Check, result checks.Result) CertificateBuilder
 	Build() (Certificate, error)
 }
 
@@ -35,13 +39,20 @@ type CheckResult struct {
 }
 
 type certificateBuilder struct {
-	ChartName      string
-	ChartVersion   string
+	ChartName           string
+	ChartVersion        string
+	ChartClassification string
+	OpenshiftVersions   []string
+	ProfileName         string
+	ProfileVersion      string
+	// FailOn is the lowest severity of the failed checks failing the certificate.
+	FailOn         checks.Severity
 	CheckResultMap checkResultMap
 }
 
 func NewCertificateBuilder() CertificateBuilder {
 	return &certificateBuilder{
+		FailOn:         checks.ErrorSeverity,
 		CheckResultMap: checkResultMap{},
 	}
 }
@@ -56,8 +67,46 @@ func (r *certificateBuilder) SetChartVersion(version string) CertificateBuilder
 	return r
 }
 
-func (r *certificateBuilder) AddCheckResult(name string, result checks.Result) CertificateBuilder {
-	r.CheckResultMap[name] = checkResult{Ok: result.Ok, Reason: result.Reason}
+func (r *certificateBuilder) SetChartClassification(classification string) CertificateBuilder {
+	r.ChartClassification = classification
+	return r
+}
+
+func (r *certificateBuilder) SetSupportedOpenshiftVersions(versions []string) CertificateBuilder {
+	r.OpenshiftVersions = versions
+	return r
+}
+
+func (r *certificateBuilder) SetFailOn(severity checks.Severity) CertificateBuilder {
+	r.FailOn = severity
+	return r
+}
+
+func (r *certificateBuilder) SetProfile(name, version string) CertificateBuilder {
+	r.ProfileName = name
+	r.ProfileVersion = version
+	return r
+}
+
+func (r *certificateBuilder) AddCheckResult(check checks.Check, result checks.Result) CertificateBuilder {
+	cr := checkResult{
+		Ok:          result.Ok,
+		Reason:      result.Reason,
+		Details:     result.Details,
+		Description: check.Description,
+		Category:    string(check.Category),
+		Version:     check.Version,
+		DocsURL:     check.DocsURL,
+		Severity:    string(check.Severity),
+	}
+	if cr.Severity == "" {
+		cr.Severity = string(checks.ErrorSeverity)
+	}
+	// how to fix the chart is only relevant when the check has failed
+	if !result.Ok {
+		cr.Remediation = check.Remediation
+	}
+	r.CheckResultMap[check.Name] = cr
 	return r
 }
 
@@ -72,12 +121,18 @@ func (r *certificateBuilder) Build() (Certificate, error) {
 
 	ok := true
 
+	// only failures at or above the failing severity fail the certificate
 	for _, v := range r.CheckResultMap {
-		if !v.Ok {
+		if !v.Ok && checks.Severity(v.Severity).AtLeast(r.FailOn) {
 			ok = false
 			break
 		}
 	}
 
-	return newCertificate(r.ChartName, r.ChartVersion, ok, r.CheckResultMap), nil
+	c := newCertificate(
+		r.ChartName, r.ChartVersion, r.ChartClassification, r.OpenshiftVersions, r.FailOn, ok, r.CheckResultMap)
+	if r.ProfileName != "" {
+		c.Metadata.Profile = &profileMetadata{Name: r.ProfileName, Version: r.ProfileVersion}
+	}
+	return c, nil
 }
@@ -17,9 +17,18 @@
 package chartverifier
 
 import (
+	"github.com/spf13/viper"
+
 	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
 )
 
+const (
+	// checksConfigKey is the config key holding the configuration of each check, by check name.
+	checksConfigKey = "checks"
+	// renderConfigKey is the config key holding the options charts are rendered with.
+	renderConfigKey = "render"
+)
+
 type CheckNotFoundErr string
 
 func (e CheckNotFoundErr) Error() string {
@@ -39,6 +48,39 @@ func NewCheckErr(err error) error {
 type certifier struct {
 	registry       checks.Registry
 	requiredChecks []string
+	config         *viper.Viper
+	// failOn is the lowest severity of the failed checks failing the certificate.
+	failOn checks.Severity
+	// profile sets the severity and parameters of the checks it contains, if any.
+	profile *Profile
+}
+
+// checkConfig returns the configuration of the given check, or nil if it hasn't been configured. The parameters the
+// profile sets for the check are defaults the config file can override.
+func (c *certifier) checkConfig(name string) *viper.Viper {
+	var config *viper.Viper
+	if c.config != nil {
+		config = c.config.Sub(checksConfigKey + "." + name)
+	}
+
+	if c.profile == nil {
+		return config
+	}
+	pc, ok := c.profile.Check(name)
+	if !ok || len(pc.Config) == 0 {
+		return config
+	}
+
+	merged := viper.New()
+	for k, v := range pc.Config {
+		merged.SetDefault(k, v)
+	}
+	if config != nil {
+		for _, k := range config.AllKeys() {
+			merged.Set(k, config.Get(k))
+		}
+	}
+	return merged
 }
 
 func (c *certifier) Certify(uri string) (Certificate, error) {
@@ -48,19 +90,55 @@ func (c *certifier) Certify(uri string) (Certificate, error) {
 		return nil, err
 	}
 
+	var renderConfig *viper.Viper
+	if c.config != nil {
+		renderConfig = c.config.Sub(renderConfigKey)
+	}
+	renderOpts, err := checks.NewRenderOptions(renderConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// charts are rendered once for all the checks of this run
+	renderCache := checks.NewRenderCache()
+
 	result := NewCertificateBuilder().
 		SetChartName(chrt.Name()).
-		SetChartVersion(chrt.AppVersion())
+		SetChartVersion(chrt.AppVersion()).
+		SetChartClassification(string(checks.ClassifyChart(chrt).Classification))
+
+	if c.failOn != "" {
+		result.SetFailOn(c.failOn)
+	}
+
+	if c.profile != nil {
+		result.SetProfile(c.profile.Name, c.profile.Version)
+	}
+
+	// the kubeVersion is validated by has-minkubeversion; an invalid one just leaves the supported versions out
+	if versions, err := checks.SupportedOpenshiftVersions(chrt.Metadata.KubeVersion); err == nil {
+		result.SetSupportedOpenshiftVersions(versions)
+	}
 
 	for _, name := range c.requiredChecks {
-		if checkFunc, ok := c.registry.Get(name); !ok {
+		if check, ok := c.registry.Get(name); !ok {
 			return nil, CheckNotFoundErr(name)
 		} else {
-			r, err := checkFunc(uri)
+			if c.profile != nil {
+				if pc, ok := c.profile.Check(name); ok {
+					check.Severity = pc.Severity()
+				}
+			}
+			r, err := check.Func(&checks.CheckOptions{
+				URI:         uri,
+				Config:      c.checkConfig(name),
+				Render:      renderOpts,
+				RenderCache: renderCache,
+			})
 			if err != nil {
 				return nil, NewCheckErr(err)
 			}
-			_ = result.AddCheckResult(name, r)
+			_ = result.AddCheckResult(check, r)
 		}
 	}
 
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
@@ -36,15 +37,15 @@ func TestCertifier_Certify(t *testing.T) {
 
 	dummyCheckName := "dummy-check"
 
-	erroredCheck := func(uri string) (checks.Result, error) {
+	erroredCheck := func(opts *checks.CheckOptions) (checks.Result, error) {
 		return checks.Result{}, errors.New("artificial error")
 	}
 
-	negativeCheck := func(uri string) (checks.Result, error) {
+	negativeCheck := func(opts *checks.CheckOptions) (checks.Result, error) {
 		return checks.Result{Ok: false}, nil
 	}
 
-	positiveCheck := func(uri string) (checks.Result, error) {
+	positiveCheck := func(opts *checks.CheckOptions) (checks.Result, error) {
 		return checks.Result{Ok: true}, nil
 	}
 
@@ -63,7 +64,7 @@ func TestCertifier_Certify(t *testing.T) {
 
 	t.Run("Should return error if check exists and returns error", func(t *testing.T) {
 		c := &certifier{
-			registry:       checks.NewRegistry().Add(dummyCheckName, erroredCheck),
+			registry:       checks.NewRegistry().Add(checks.Check{Name: dummyCheckName, Func: erroredCheck}),
 			requiredChecks: []string{dummyCheckName},
 		}
 
@@ -75,7 +76,7 @@ func TestCertifier_Certify(t *testing.T) {
 	t.Run("Result should be negative if check exists and returns negative", func(t *testing.T) {
 
 		c := &certifier{
-			registry:       checks.NewRegistry().Add(dummyCheckName, negativeCheck),
+			registry:       checks.NewRegistry().Add(checks.Check{Name: dummyCheckName, Func: negativeCheck}),
 			requiredChecks: []string{dummyCheckName},
 		}
 
@@ -87,7 +88,7 @@ func TestCertifier_Certify(t *testing.T) {
 
 	t.Run("Result should be positive if check exists and returns positive", func(t *testing.T) {
 		c := &certifier{
-			registry:       checks.NewRegistry().Add(dummyCheckName, positiveCheck),
+			registry:       checks.NewRegistry().Add(checks.Check{Name: dummyCheckName, Func: positiveCheck}),
 			requiredChecks: []string{dummyCheckName},
 		}
 
@@ -97,5 +98,85 @@ func TestCertifier_Certify(t *testing.T) {
 		require.True(t, r.IsOk())
 	})
 
+	t.Run("Result should only be negative for failures at or above the failing severity", func(t *testing.T) {
+		warningCheck := checks.Check{Name: dummyCheckName, Severity: checks.WarningSeverity, Func: negativeCheck}
+
+		c := &certifier{
+			registry:       checks.NewRegistry().Add(warningCheck),
+			requiredChecks: []string{dummyCheckName},
+		}
+
+		r, err := c.Certify(validChartUri)
+		require.NoError(t, err)
+		require.True(t, r.IsOk())
+
+		c.failOn = checks.WarningSeverity
+		r, err = c.Certify(validChartUri)
+		require.NoError(t, err)
+		require.False(t, r.IsOk())
+	})
+
+	t.Run("Should apply the profile severity and parameters to the check", func(t *testing.T) {
+		config := viper.New()
+		config.Set("checks."+dummyCheckName+".overridden", "config")
+
+		var checkConfig *viper.Viper
+		profiledCheck := func(opts *checks.CheckOptions) (checks.Result, error) {
+			checkConfig = opts.Config
+			return checks.Result{Ok: false}, nil
+		}
+
+		profile := &Profile{
+			Name:    "test",
+			Version: "2.1",
+			Checks: []ProfileCheck{
+				advisory(dummyCheckName, "", map[string]interface{}{"overridden": "profile", "kept": "profile"}),
+			},
+		}
+
+		c := &certifier{
+			registry: checks.NewRegistry().Add(
+				checks.Check{Name: dummyCheckName, Severity: checks.ErrorSeverity, Func: profiledCheck}),
+			requiredChecks: []string{dummyCheckName},
+			config:         config,
+			profile:        profile,
+		}
+
+		r, err := c.Certify(validChartUri)
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		// the check is advisory in the profile, so its failure is only a warning
+		require.True(t, r.IsOk())
+
+		cert := r.(*certificate)
+		require.Equal(t, &profileMetadata{Name: "test", Version: "2.1"}, cert.Metadata.Profile)
+		require.Equal(t, string(checks.WarningSeverity), cert.CheckResultMap[dummyCheckName].Severity)
+
+		require.NotNil(t, checkConfig)
+		require.True(t, checkConfig.IsSet("kept"))
+		require.Equal(t, "profile", checkConfig.GetString("kept"))
+		require.Equal(t, "config", checkConfig.GetString("overridden"))
+	})
+
+	t.Run("Should inform the check configuration to the check", func(t *testing.T) {
+		config := viper.New()
+		config.Set("checks."+dummyCheckName+".answer", "42")
+
+		configuredCheck := func(opts *checks.CheckOptions) (checks.Result, error) {
+			return checks.Result{Ok: opts.Config != nil && opts.Config.GetString("answer") == "42"}, nil
+		}
+
+		c := &certifier{
+			registry:       checks.NewRegistry().Add(checks.Check{Name: dummyCheckName, Func: configuredCheck}),
+			requiredChecks: []string{dummyCheckName},
+			config:         config,
+		}
+
+		r, err := c.Certify(validChartUri)
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		require.True(t, r.IsOk())
+	})
+
 	cancel()
 }
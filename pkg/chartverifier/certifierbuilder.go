@@ -19,6 +19,8 @@ package chartverifier
 import (
 	"errors"
 
+	"github.com/spf13/viper"
+
 	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
 )
 
@@ -26,14 +28,9 @@ var defaultRegistry checks.Registry
 
 func init() {
 	defaultRegistry = checks.NewRegistry()
-	defaultRegistry.Add("has-readme", checks.HasReadme)
-	defaultRegistry.Add("is-helm-v3", checks.IsHelmV3)
-	defaultRegistry.Add("contains-test", checks.ContainsTest)
-	defaultRegistry.Add("contains-values", checks.ContainsValues)
-	defaultRegistry.Add("contains-values-schema", checks.ContainsValuesSchema)
-	defaultRegistry.Add("has-minkubeversion", checks.HasMinKubeVersion)
-	defaultRegistry.Add("not-contains-crds", checks.NotContainCRDs)
-	defaultRegistry.Add("helm-lint", checks.HelmLint)
+	for _, check := range defaultChecks {
+		defaultRegistry.Add(check)
+	}
 }
 
 func DefaultRegistry() checks.Registry {
@@ -43,6 +40,9 @@ func DefaultRegistry() checks.Registry {
 type certifierBuilder struct {
 	registry checks.Registry
 	checks   []string
+	config   *viper.Viper
+	failOn   checks.Severity
+	profile  *Profile
 }
 
 func (b *certifierBuilder) SetRegistry(registry checks.Registry) CertifierBuilder {
@@ -55,6 +55,21 @@ func (b *certifierBuilder) SetChecks(checks []string) CertifierBuilder {
 	return b
 }
 
+func (b *certifierBuilder) SetConfig(config *viper.Viper) CertifierBuilder {
+	b.config = config
+	return b
+}
+
+func (b *certifierBuilder) SetFailOn(severity checks.Severity) CertifierBuilder {
+	b.failOn = severity
+	return b
+}
+
+func (b *certifierBuilder) SetProfile(profile *Profile) CertifierBuilder {
+	b.profile = profile
+	return b
+}
+
 func (b *certifierBuilder) Build() (Certifier, error) {
 	if len(b.checks) == 0 {
 		return nil, errors.New("no checks have been required")
@@ -64,9 +79,22 @@ func (b *certifierBuilder) Build() (Certifier, error) {
 		b.registry = defaultRegistry
 	}
 
+	if b.profile != nil {
+		if err := b.profile.checkVersions(b.registry, b.checks); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.failOn == "" {
+		b.failOn = checks.ErrorSeverity
+	}
+
 	return &certifier{
 		registry:       b.registry,
 		requiredChecks: b.checks,
+		config:         b.config,
+		failOn:         b.failOn,
+		profile:        b.profile,
 	}, nil
 }
 
@@ -17,9 +17,13 @@
 package chartverifier
 
 import (
+	"io/ioutil"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
 )
 
 func TestCertificationBuilder(t *testing.T) {
@@ -42,4 +46,52 @@ func TestCertificationBuilder(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, c)
 	})
+
+	t.Run("Should fail building certifier when a profile check has another version", func(t *testing.T) {
+		profile, err := GetProfile("partner@1.0")
+		require.NoError(t, err)
+
+		c, err := NewCertifierBuilder().
+			SetRegistry(checks.NewRegistry().Add(checks.Check{Name: "is-helm-v3", Version: "2.0"})).
+			SetChecks([]string{"is-helm-v3"}).
+			SetProfile(profile).
+			Build()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `profile partner@1.0 requires version "1.0" of check "is-helm-v3"`)
+		require.Nil(t, c)
+
+		c, err = NewCertifierBuilder().
+			SetChecks([]string{"is-helm-v3"}).
+			SetProfile(profile).
+			Build()
+		require.NoError(t, err)
+		require.NotNil(t, c)
+	})
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	readme, err := ioutil.ReadFile("../../README.md")
+	require.NoError(t, err)
+
+	documented := make([]string, 0)
+	for _, m := range regexp.MustCompile("(?m)^\\| `([a-z0-9-]+)` \\| ([a-z]+) \\|").FindAllStringSubmatch(string(readme), -1) {
+		documented = append(documented, m[1]+" "+m[2])
+	}
+
+	registered := make([]string, 0, len(defaultChecks))
+	for _, check := range defaultChecks {
+		registered = append(registered, check.Name+" "+string(check.Severity))
+
+		require.NotEmpty(t, check.Description, check.Name)
+		require.NotEmpty(t, check.Category, check.Name)
+		require.NotEmpty(t, check.Remediation, check.Name)
+		require.NotEmpty(t, check.DocsURL, check.Name)
+		require.NotEmpty(t, check.Version, check.Name)
+		require.NotEmpty(t, check.Severity, check.Name)
+		require.NotNil(t, check.Func, check.Name)
+	}
+
+	// the README table lists the default checks and their severities in the same order
+	require.Equal(t, documented, registered)
+	require.Len(t, DefaultRegistry().AllChecks(), len(defaultChecks))
 }
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxCategorySuggestions is the maximum number of categories suggested for a keyword not mapped to any category.
+const maxCategorySuggestions = 3
+
+// OpenshiftCategory is a category of the OpenShift developer catalog.
+type OpenshiftCategory struct {
+	// ID is the category identifier used by the developer catalog.
+	ID string
+	// Label is the human readable name of the category.
+	Label string
+	// Keywords are the tags mapping a chart into the category.
+	Keywords []string
+}
+
+// OpenshiftCategoryCatalog is a versioned list of the OpenShift developer catalog categories.
+type OpenshiftCategoryCatalog struct {
+	// Version is the OpenShift release the catalog has been extracted from.
+	Version    string
+	Categories []OpenshiftCategory
+}
+
+// DefaultOpenshiftCategoryCatalog contains the categories of the OpenShift developer catalog the chart keywords are
+// compared with.
+var DefaultOpenshiftCategoryCatalog = OpenshiftCategoryCatalog{
+	Version: "4.7",
+	Categories: []OpenshiftCategory{
+		{ID: "languages", Label: "Languages", Keywords: []string{"languages"}},
+		{ID: "java", Label: "Languages/Java", Keywords: []string{"java", "jdk", "openjdk"}},
+		{ID: "javascript", Label: "Languages/JavaScript", Keywords: []string{"javascript", "nodejs", "node.js", "js"}},
+		{ID: "dotnet", Label: "Languages/.NET", Keywords: []string{"dotnet", ".net", "dotnetcore"}},
+		{ID: "perl", Label: "Languages/Perl", Keywords: []string{"perl"}},
+		{ID: "ruby", Label: "Languages/Ruby", Keywords: []string{"ruby", "rails"}},
+		{ID: "php", Label: "Languages/PHP", Keywords: []string{"php"}},
+		{ID: "python", Label: "Languages/Python", Keywords: []string{"python", "django"}},
+		{ID: "golang", Label: "Languages/Go", Keywords: []string{"golang", "go"}},
+		{ID: "databases", Label: "Databases", Keywords: []string{"database", "databases"}},
+		{ID: "mongodb", Label: "Databases/Mongo", Keywords: []string{"mongodb", "mongo"}},
+		{ID: "mysql", Label: "Databases/MySQL", Keywords: []string{"mysql"}},
+		{ID: "postgresql", Label: "Databases/Postgres", Keywords: []string{"postgresql", "postgres"}},
+		{ID: "mariadb", Label: "Databases/MariaDB", Keywords: []string{"mariadb"}},
+		{ID: "redis", Label: "Databases/Redis", Keywords: []string{"redis"}},
+		{ID: "middleware", Label: "Middleware", Keywords: []string{"middleware"}},
+		{ID: "integration", Label: "Middleware/Integration",
+			Keywords: []string{"integration", "amq", "fuse", "jboss-fuse", "sso", "3scale", "messaging", "kafka"}},
+		{ID: "processAutomation", Label: "Middleware/Process Automation",
+			Keywords: []string{"decisionserver", "processserver", "workflow"}},
+		{ID: "analyticsData", Label: "Middleware/Analytics & Data",
+			Keywords: []string{"analytics", "datagrid", "datavirt", "cache"}},
+		{ID: "runtimes", Label: "Middleware/Runtimes & Frameworks",
+			Keywords: []string{"runtime", "eap", "httpd", "tomcat", "quarkus", "spring"}},
+		{ID: "cicd", Label: "CI/CD", Keywords: []string{"cicd", "ci", "cd"}},
+		{ID: "jenkins", Label: "CI/CD/Jenkins", Keywords: []string{"jenkins"}},
+		{ID: "pipelines", Label: "CI/CD/Pipelines", Keywords: []string{"pipeline", "pipelines", "tekton"}},
+		{ID: "virtualization", Label: "Virtualization", Keywords: []string{"virtualization", "kubevirt", "vm"}},
+		{ID: "monitoring", Label: "Monitoring",
+			Keywords: []string{"monitoring", "metrics", "prometheus", "grafana", "logging"}},
+		{ID: "networking", Label: "Networking", Keywords: []string{"networking", "ingress", "proxy", "loadbalancer"}},
+		{ID: "security", Label: "Security", Keywords: []string{"security", "authentication", "authorization", "secrets"}},
+		{ID: "storage", Label: "Storage", Keywords: []string{"storage", "backup"}},
+		{ID: "ai-ml", Label: "AI/Machine Learning", Keywords: []string{"ai", "ml", "machine-learning"}},
+	},
+}
+
+// KeywordMatch holds the result of mapping a single keyword into the catalog.
+type KeywordMatch struct {
+	Keyword string
+	// Category is the category the keyword is mapped to; nil when the keyword is not mapped.
+	Category *OpenshiftCategory
+	// Suggestions are the categories closest to the keyword, only populated when the keyword is not mapped.
+	Suggestions []OpenshiftCategory
+}
+
+// MatchKeywords maps each of the given keywords into a category of the catalog, suggesting the closest categories for
+// keywords without a match.
+func (c OpenshiftCategoryCatalog) MatchKeywords(keywords []string) []KeywordMatch {
+	matches := make([]KeywordMatch, 0, len(keywords))
+	for _, k := range keywords {
+		m := KeywordMatch{Keyword: k}
+		if category, ok := c.lookup(k); ok {
+			m.Category = &category
+		} else {
+			m.Suggestions = c.suggest(k)
+		}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+func normalizeKeyword(keyword string) string {
+	return strings.ToLower(strings.TrimSpace(keyword))
+}
+
+func (c OpenshiftCategoryCatalog) lookup(keyword string) (OpenshiftCategory, bool) {
+	keyword = normalizeKeyword(keyword)
+	for _, category := range c.Categories {
+		if strings.ToLower(category.ID) == keyword {
+			return category, true
+		}
+		for _, k := range category.Keywords {
+			if k == keyword {
+				return category, true
+			}
+		}
+	}
+	return OpenshiftCategory{}, false
+}
+
+// suggest returns the categories whose keywords are closest to the given keyword, ordered by edit distance.
+func (c OpenshiftCategoryCatalog) suggest(keyword string) []OpenshiftCategory {
+	keyword = normalizeKeyword(keyword)
+	if keyword == "" {
+		return nil
+	}
+
+	type candidate struct {
+		category OpenshiftCategory
+		distance int
+	}
+
+	candidates := make([]candidate, 0, len(c.Categories))
+	for _, category := range c.Categories {
+		best := -1
+		for _, k := range append([]string{strings.ToLower(category.ID)}, category.Keywords...) {
+			if d := levenshtein(keyword, k); best < 0 || d < best {
+				best = d
+			}
+		}
+		// only consider categories requiring at most one edit for every three characters of the keyword
+		if best <= len(keyword)/3 {
+			candidates = append(candidates, candidate{category: category, distance: best})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	suggestions := make([]OpenshiftCategory, 0, maxCategorySuggestions)
+	for i := 0; i < len(candidates) && i < maxCategorySuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].category)
+	}
+	return suggestions
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
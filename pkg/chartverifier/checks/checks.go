@@ -19,39 +19,79 @@ package checks
 import (
 	"fmt"
 	"helm.sh/helm/v3/pkg/lint"
+	"helm.sh/helm/v3/pkg/provenance"
 	"path"
 	"strings"
-
-	"github.com/pkg/errors"
 )
 
 const (
-	APIVersion2                  = "v2"
-	ReadmeExist                  = "Chart has a README"
-	ReadmeDoesNotExist           = "Chart does not have a README"
-	NotHelm3Reason               = "API version is not V2, used in Helm 3"
-	Helm3Reason                  = "API version is V2, used in Helm 3"
-	TestTemplatePrefix           = "templates/tests/"
-	ChartTestFilesExist          = "Chart test files exist"
-	ChartTestFilesDoesNotExist   = "Chart test files do not exist"
-	MinKuberVersionSpecified     = "Minimum Kubernetes version specified"
-	MinKuberVersionNotSpecified  = "Minimum Kubernetes version is not specified"
-	ValuesSchemaFileExist        = "Values schema file exist"
-	ValuesSchemaFileDoesNotExist = "Values schema file does not exist"
-	ValuesFileExist              = "Values file exist"
-	ValuesFileDoesNotExist       = "Values file does not exist"
-	ChartContainCRDs             = "Chart contains CRDs"
-	ChartDoesNotContainCRDs      = "Chart does not contain CRDs"
-	HelmLintSuccessful           = "Helm lint successful"
-	HelmLintHasFailedPrefix      = "Helm lint has failed: "
+	APIVersion2                               = "v2"
+	ReadmeExist                               = "Chart has a README"
+	ReadmeDoesNotExist                        = "Chart does not have a README"
+	NotHelm3Reason                            = "API version is not V2, used in Helm 3"
+	Helm3Reason                               = "API version is V2, used in Helm 3"
+	ChartTestFilesExist                       = "Chart test hooks exist"
+	ChartTestFilesDoesNotExist                = "Chart test hooks do not exist"
+	MinKuberVersionSpecified                  = "Minimum Kubernetes version specified"
+	MinKuberVersionNotSpecified               = "Minimum Kubernetes version is not specified"
+	MinKubeVersionIsNewerThanKnown            = "Minimum Kubernetes version specified, newer than the known Kubernetes versions"
+	ValuesSchemaFileExist                     = "Values schema file exist"
+	ValuesSchemaFileDoesNotExist              = "Values schema file does not exist"
+	ValuesFileExist                           = "Values file exist"
+	ValuesFileDoesNotExist                    = "Values file does not exist"
+	ChartContainCRDs                          = "Chart contains CRDs"
+	ChartDoesNotContainCRDs                   = "Chart does not contain CRDs"
+	HelmLintSuccessful                        = "Helm lint successful"
+	HelmLintHasFailedPrefix                   = "Helm lint has failed: "
+	KeywordsMapToCategories                   = "Chart keywords are mapped to OpenShift categories"
+	KeywordsDoNotMapToCategories              = "Chart keywords are not mapped to OpenShift categories"
+	ChartIsCommercial                         = "Chart is a commercial chart"
+	ChartIsNotCommercial                      = "Chart is not a commercial chart"
+	ChartIsCommunity                          = "Chart is a community chart"
+	ChartIsNotCommunity                       = "Chart is not a community chart"
+	ChartRenderFailedPrefix                   = "Chart could not be rendered: "
+	ChartContainsInfraPlugins                 = "Chart contains infra plugins and drivers"
+	ChartDoesNotContainInfra                  = "Chart does not contain infra plugins and drivers"
+	ChartRequiresClusterAdminPrivileges       = "Chart requires cluster admin privileges to be installed"
+	ChartDoesNotRequireClusterAdminPrivileges = "Chart can be installed without cluster admin privileges"
+	ChartRequiresManualPrerequisites          = "Chart requires manual prerequisites to be installed"
+	ChartDoesNotRequireManualPrerequisites    = "Chart can be installed without manual prerequisites"
+	ReadmeDocumentsValues                     = "Chart README documents the chart values"
+	ReadmeDoesNotDocumentValues               = "Chart README does not document the chart values"
+	ReadmeDoesNotContainValuesTable           = "Chart README does not contain a values table"
+	ImagesPinnedByDigest                      = "Chart images are pinned by digest"
+	ImagesNotPinnedByDigest                   = "Chart images are not pinned by digest"
+	ImagesFromAllowedRegistries               = "Chart images are pulled from allowed registries"
+	ImagesNotFromAllowedRegistries            = "Chart images are not pulled from allowed registries"
+	ValuesMatchSchema                         = "Values are valid against the values schema"
+	ValuesDoNotMatchSchema                    = "Values are not valid against the values schema"
+	ValuesSchemaIsNotValid                    = "Values schema is not a valid JSON Schema"
+	ContainersSetResources                    = "Chart containers set resource requests and limits"
+	ContainersDoNotSetResources               = "Chart containers do not set resource requests and limits"
+	PodSecurityStandardsMetPrefix             = "Chart pods meet the Pod Security Standards at level: "
+	PodSecurityStandardsNotMetPrefix          = "Chart pods do not meet the Pod Security Standards at level: "
+	ChartIsRestrictedSCCCompatible            = "Chart can run under the OpenShift restricted SCC"
+	ChartIsNotRestrictedSCCCompatible         = "Chart cannot run under the OpenShift restricted SCC"
+	KubeVersionIsNotValidPrefix               = "Chart kubeVersion is not a valid constraint: "
+	KubeAPIsAreAvailable                      = "Chart APIs are available across its kubeVersion range"
+	KubeAPIsAreNotAvailable                   = "Chart APIs are not available across its kubeVersion range"
+	KubeAPIsAreNotKnown                       = "Chart kubeVersion range is newer than the known Kubernetes APIs"
+	ChartMetadataIsComplete                   = "Chart metadata is complete"
+	ChartMetadataIsNotComplete                = "Chart metadata is missing or has malformed fields"
+	ChartHasNoDependencies                    = "Chart has no dependencies"
+	DependenciesLockedAndVendored             = "Chart dependencies are pinned, locked and vendored"
+	DependenciesNotLockedAndVendored          = "Chart dependencies are not pinned, locked and vendored"
+	ProbesAreDefined                          = "Chart long-running containers have readiness and liveness probes"
+	ProbesAreNotDefined                       = "Chart long-running containers do not have valid probes"
+	ProvenanceIsVerified                      = "Chart is signed by a known publisher and matches its provenance"
+	ProvenanceIsNotFound                      = "Chart provenance file not found"
+	ProvenanceIsNotVerifiedPrefix             = "Chart provenance could not be verified: "
+	ProvenanceDigestDoesNotMatch              = "Chart archive digest does not match its signed provenance"
+	RuleIsSatisfiedPrefix                     = "Chart satisfies the rule: "
 )
 
-func notImplemented() (Result, error) {
-	return Result{Ok: false}, errors.New("not implemented")
-}
-
-func IsHelmV3(uri string) (Result, error) {
-	c, _, err := LoadChartFromURI(uri)
+func IsHelmV3(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
 		return Result{}, err
 	}
@@ -64,8 +104,8 @@ func IsHelmV3(uri string) (Result, error) {
 	return Result{Ok: isHelmV3, Reason: reason}, nil
 }
 
-func HasReadme(uri string) (Result, error) {
-	c, _, err := LoadChartFromURI(uri)
+func HasReadme(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
 		return Result{}, err
 	}
@@ -81,27 +121,82 @@ func HasReadme(uri string) (Result, error) {
 	return r, nil
 }
 
-func ContainsTest(uri string) (Result, error) {
-	c, _, err := LoadChartFromURI(uri)
+func ReadmeContainsValuesSchema(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
 		return Result{}, err
 	}
 
-	r := Result{Reason: ChartTestFilesDoesNotExist}
-	for _, f := range c.Templates {
-		if strings.HasPrefix(f.Name, TestTemplatePrefix) && strings.HasSuffix(f.Name, ".yaml") {
-			r.Reason = ChartTestFilesExist
-			r.Ok = true
-			break
+	var readme string
+	found := false
+	for _, f := range c.Files {
+		if f.Name == "README.md" {
+			readme = string(f.Data)
+			found = true
 		}
 	}
+	if !found {
+		return Result{Reason: ReadmeDoesNotExist}, nil
+	}
+
+	documented, ok := readmeValuesKeys(readme)
+	if !ok {
+		return Result{Reason: ReadmeDoesNotContainValuesTable}, nil
+	}
+
+	undocumented, stale := compareReadmeValues(documented, flattenValues(c.Values))
+
+	r := Result{Ok: true, Reason: ReadmeDocumentsValues}
+	for _, k := range undocumented {
+		r.Details = append(r.Details, fmt.Sprintf("value %q is not documented in README", k))
+	}
+	for _, k := range stale {
+		r.Details = append(r.Details, fmt.Sprintf("README documents %q, which does not exist in values", k))
+	}
+	if len(r.Details) > 0 {
+		r.Ok = false
+		r.Reason = ReadmeDoesNotDocumentValues
+	}
 
 	return r, nil
+}
+
+func ContainsTest(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	tests := make([]string, 0)
+	problems := make([]string, 0)
+	for _, o := range objects {
+		if !isTestHook(o) {
+			continue
+		}
+		problem, err := testHookProblem(o)
+		if err != nil {
+			return Result{}, err
+		}
+		if problem != "" {
+			problems = append(problems, problem)
+			continue
+		}
+		tests = append(tests, objectName(o))
+	}
+
+	if len(tests) == 0 {
+		return Result{Reason: ChartTestFilesDoesNotExist, Details: problems}, nil
+	}
 
+	return Result{Ok: true, Reason: ChartTestFilesExist, Details: tests}, nil
 }
 
-func ContainsValues(uri string) (Result, error) {
-	c, _, err := LoadChartFromURI(uri)
+func ContainsValues(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
 		return Result{}, err
 	}
@@ -116,8 +211,8 @@ func ContainsValues(uri string) (Result, error) {
 	return r, nil
 }
 
-func ContainsValuesSchema(uri string) (Result, error) {
-	c, _, err := LoadChartFromURI(uri)
+func ContainsValuesSchema(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
 		return Result{}, err
 	}
@@ -132,36 +227,124 @@ func ContainsValuesSchema(uri string) (Result, error) {
 	return r, nil
 }
 
-func KeywordsAreOpenshiftCategories(uri string) (Result, error) {
-	return notImplemented()
-}
+func ValuesAreValidAgainstSchema(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if len(c.Schema) == 0 {
+		return Result{Reason: ValuesSchemaFileDoesNotExist}, nil
+	}
+
+	schema, err := compileValuesSchema(c.Schema)
+	if err != nil {
+		return Result{Reason: ValuesSchemaIsNotValid, Details: []string{err.Error()}}, nil
+	}
+
+	violations, err := valuesSchemaViolations(schema, c.Values)
+	if err != nil {
+		return Result{}, err
+	}
+
+	r := Result{Ok: true, Reason: ValuesMatchSchema}
+	if len(violations) > 0 {
+		r.Ok = false
+		r.Reason = ValuesDoNotMatchSchema
+		r.Details = violations
+	}
 
-func IsCommercialChart(uri string) (Result, error) {
-	return notImplemented()
+	return r, nil
 }
 
-func IsCommunityChart(uri string) (Result, error) {
-	return notImplemented()
+func KeywordsAreOpenshiftCategories(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return Result{}, err
+	}
+
+	catalog := DefaultOpenshiftCategoryCatalog
+	r := Result{
+		Reason:  KeywordsDoNotMapToCategories,
+		Details: []string{"OpenShift category catalog version: " + catalog.Version},
+	}
+
+	for _, m := range catalog.MatchKeywords(c.Metadata.Keywords) {
+		if m.Category != nil {
+			r.Ok = true
+			r.Reason = KeywordsMapToCategories
+			r.Details = append(r.Details, fmt.Sprintf("keyword %q matches category %q", m.Keyword, m.Category.Label))
+			continue
+		}
+		detail := fmt.Sprintf("keyword %q does not match any category", m.Keyword)
+		if len(m.Suggestions) > 0 {
+			labels := make([]string, 0, len(m.Suggestions))
+			for _, s := range m.Suggestions {
+				labels = append(labels, s.Label)
+			}
+			detail += fmt.Sprintf(", closest categories: %s", strings.Join(labels, ", "))
+		}
+		r.Details = append(r.Details, detail)
+	}
+
+	return r, nil
 }
 
-func HasMinKubeVersion(uri string) (Result, error) {
+// isClassifiedAs checks whether the chart in the given uri has the expected classification, reporting the evidence the
+// classification has been based on.
+func isClassifiedAs(uri string, expected ChartClassification, positiveReason, negativeReason string) (Result, error) {
 	c, _, err := LoadChartFromURI(uri)
 	if err != nil {
 		return Result{}, err
 	}
 
-	r := Result{Reason: MinKuberVersionNotSpecified}
+	classification := ClassifyChart(c)
 
-	if c.Metadata.KubeVersion != "" {
+	r := Result{Reason: negativeReason, Details: classification.Evidence}
+	if classification.Classification == expected {
 		r.Ok = true
-		r.Reason = MinKuberVersionSpecified
+		r.Reason = positiveReason
 	}
 
 	return r, nil
 }
 
-func NotContainCRDs(uri string) (Result, error) {
-	c, _, err := LoadChartFromURI(uri)
+func IsCommercialChart(opts *CheckOptions) (Result, error) {
+	return isClassifiedAs(opts.URI, CommercialChart, ChartIsCommercial, ChartIsNotCommercial)
+}
+
+func IsCommunityChart(opts *CheckOptions) (Result, error) {
+	return isClassifiedAs(opts.URI, CommunityChart, ChartIsCommunity, ChartIsNotCommunity)
+}
+
+func HasMinKubeVersion(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if c.Metadata.KubeVersion == "" {
+		return Result{Reason: MinKuberVersionNotSpecified}, nil
+	}
+
+	constraint, err := ParseKubeVersion(c.Metadata.KubeVersion)
+	if err != nil {
+		return Result{Reason: KubeVersionIsNotValidPrefix + err.Error()}, nil
+	}
+
+	if IsNewerThanKnownKubeVersions(constraint) {
+		return Result{
+			Ok:      true,
+			Reason:  MinKubeVersionIsNewerThanKnown,
+			Details: []string{"the latest known Kubernetes version is " + DefaultKubeAPICatalog.KubeVersion},
+		}, nil
+	}
+
+	return Result{Ok: true, Reason: MinKuberVersionSpecified}, nil
+}
+
+func NotContainCRDs(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
 	if err != nil {
 		return Result{}, err
 	}
@@ -176,32 +359,484 @@ func NotContainCRDs(uri string) (Result, error) {
 	return r, nil
 }
 
-func HelmLint(uri string) (Result, error) {
-	c, p, err := LoadChartFromURI(uri)
+func HelmLint(opts *CheckOptions) (Result, error) {
+	c, p, err := LoadChartFromURI(opts.URI)
 	if err != nil {
 		return Result{}, err
 	}
+
+	lintOpts, err := newHelmLintOptions(opts.Config)
+	if err != nil {
+		return Result{}, err
+	}
+
 	r := Result{Ok: true, Reason: HelmLintSuccessful}
 	p = path.Join(p, c.Name())
-	linter := lint.All(p, map[string]interface{}{}, "default", false)
-	if len(linter.Messages) > 0 {
-		reason := ""
-		for _, m := range linter.Messages {
-			reason = reason + m.Error() + "\n"
+	linter := lint.All(p, lintOpts.Values, lintOpts.Namespace, lintOpts.Strict)
+
+	failures := 0
+	for _, m := range linter.Messages {
+		r.Details = append(r.Details, m.Error())
+		if m.Severity >= lintOpts.Severity {
+			failures++
 		}
-		r = Result{Ok: false, Reason: fmt.Sprintf("%s %s", HelmLintHasFailedPrefix, reason)}
 	}
+	if failures > 0 {
+		r.Ok = false
+		r.Reason = fmt.Sprintf("%s%d message(s) at or above the failing severity", HelmLintHasFailedPrefix, failures)
+	}
+
 	return r, nil
 }
 
-func NotContainsInfraPluginsAndDrivers(uri string) (Result, error) {
-	return notImplemented()
+func ImagesArePinnedByDigest(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ImagesPinnedByDigest}
+	for _, o := range objects {
+		spec, ok, err := podSpecOf(o)
+		if err != nil {
+			return Result{}, err
+		}
+		if !ok {
+			continue
+		}
+		for _, ci := range podSpecImages(spec) {
+			ref := parseImageReference(ci.Image)
+			if ref.IsPinned() {
+				continue
+			}
+
+			var problem string
+			switch ref.Tag {
+			case "":
+				problem = "is not tagged nor pinned by digest"
+			case latestTag:
+				problem = "uses the mutable latest tag instead of a digest"
+			default:
+				problem = "is pinned by tag instead of digest"
+			}
+
+			r.Ok = false
+			r.Reason = ImagesNotPinnedByDigest
+			r.Details = append(r.Details,
+				fmt.Sprintf("%s container %q: image %q %s", objectName(o), ci.Container, ci.Image, problem))
+		}
+	}
+
+	return r, nil
 }
 
-func CanBeInstalledWithoutManualPreRequisites(uri string) (Result, error) {
-	return notImplemented()
+func ImagesAreFromAllowedRegistries(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	allowed := DefaultAllowedImageRegistries
+	if opts.Config != nil && opts.Config.IsSet(AllowedRegistriesConfigKey) {
+		allowed = opts.Config.GetStringSlice(AllowedRegistriesConfigKey)
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ImagesFromAllowedRegistries}
+	for _, o := range objects {
+		spec, ok, err := podSpecOf(o)
+		if err != nil {
+			return Result{}, err
+		}
+		if !ok {
+			continue
+		}
+		for _, ci := range podSpecImages(spec) {
+			ref := parseImageReference(ci.Image)
+			if ref.IsFromAllowedRegistry(allowed) {
+				continue
+			}
+			r.Ok = false
+			r.Reason = ImagesNotFromAllowedRegistries
+			r.Details = append(r.Details, fmt.Sprintf("%s container %q: image %q is pulled from %q, which is not allowed",
+				objectName(o), ci.Container, ci.Image, ref.Registry))
+		}
+	}
+
+	return r, nil
 }
 
-func CanBeInstalledWithoutClusterAdminPrivileges(uri string) (Result, error) {
-	return notImplemented()
+func NotContainsInfraPluginsAndDrivers(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ChartDoesNotContainInfra}
+	for _, o := range objects {
+		reason, err := infraPluginReason(o)
+		if err != nil {
+			return Result{}, err
+		}
+		if reason != "" {
+			r.Ok = false
+			r.Reason = ChartContainsInfraPlugins
+			r.Details = append(r.Details, objectName(o)+": "+reason)
+		}
+	}
+
+	return r, nil
+}
+
+func CanBeInstalledWithoutManualPreRequisites(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	rendered, err := renderCheckedChart(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ChartDoesNotRequireManualPrerequisites}
+
+	for _, e := range rendered.Errors {
+		var detail string
+		switch {
+		case e.IsRequiredValue():
+			detail = fmt.Sprintf("template %s requires a value without default: %s", e.Template, e.Err)
+		case e.IsFailGuard():
+			detail = fmt.Sprintf("template %s fails with the default values: %s", e.Template, e.FailMessage())
+		default:
+			return Result{Reason: ChartRenderFailedPrefix + e.Err.Error()}, nil
+		}
+		r.Ok = false
+		r.Reason = ChartRequiresManualPrerequisites
+		r.Details = append(r.Details, detail)
+	}
+
+	reported := map[string]bool{}
+	for _, o := range rendered.Objects {
+		spec, ok, err := podSpecOf(o)
+		if err != nil {
+			return Result{}, err
+		}
+		if !ok {
+			continue
+		}
+		for _, ref := range podSpecReferences(spec) {
+			detail := fmt.Sprintf("%s referenced by %s is not created by the chart", ref, objectName(o))
+			if _, provided := rendered.Get(ref.GroupVersionKind(), ref.Name); provided || reported[detail] {
+				continue
+			}
+			reported[detail] = true
+			r.Ok = false
+			r.Reason = ChartRequiresManualPrerequisites
+			r.Details = append(r.Details, detail)
+		}
+	}
+
+	return r, nil
+}
+
+func CanBeInstalledWithoutClusterAdminPrivileges(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ChartDoesNotRequireClusterAdminPrivileges}
+	for _, o := range objects {
+		if isClusterScoped(o) {
+			r.Ok = false
+			r.Reason = ChartRequiresClusterAdminPrivileges
+			r.Details = append(r.Details, objectName(o)+" is cluster-scoped")
+		}
+	}
+
+	return r, nil
+}
+
+func ContainersSetResourceRequestsAndLimits(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ContainersSetResources}
+	for _, o := range objects {
+		spec, ok, err := podSpecOf(o)
+		if err != nil {
+			return Result{}, err
+		}
+		if !ok {
+			continue
+		}
+		if problems := podSpecResourceProblems(spec); len(problems) > 0 {
+			r.Ok = false
+			r.Reason = ContainersDoNotSetResources
+			r.Details = append(r.Details, fmt.Sprintf("%s: %s", objectName(o), strings.Join(problems, "; ")))
+		}
+	}
+
+	return r, nil
+}
+
+func PodsMeetPodSecurityStandards(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	level := DefaultPodSecurityLevel
+	if opts.Config != nil && opts.Config.IsSet(PodSecurityLevelConfigKey) {
+		var err error
+		level, err = parsePodSecurityLevel(opts.Config.GetString(PodSecurityLevelConfigKey))
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: PodSecurityStandardsMetPrefix + string(level)}
+	for _, o := range objects {
+		spec, ok, err := podSpecOf(o)
+		if err != nil {
+			return Result{}, err
+		}
+		if !ok {
+			continue
+		}
+		for _, v := range podSecurityViolations(spec, level) {
+			r.Ok = false
+			r.Reason = PodSecurityStandardsNotMetPrefix + string(level)
+			r.Details = append(r.Details, fmt.Sprintf("%s %s", objectName(o), v))
+		}
+	}
+
+	return r, nil
+}
+
+func IsRestrictedSCCCompatible(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ChartIsRestrictedSCCCompatible}
+	for _, o := range objects {
+		problems, ok, err := sccUseGrants(o)
+		if err != nil {
+			return Result{}, err
+		}
+		if !ok {
+			spec, ok, err := podSpecOf(o)
+			if err != nil {
+				return Result{}, err
+			}
+			if !ok {
+				continue
+			}
+			problems = restrictedSCCProblems(spec)
+		}
+		for _, p := range problems {
+			r.Ok = false
+			r.Reason = ChartIsNotRestrictedSCCCompatible
+			r.Details = append(r.Details, fmt.Sprintf("%s %s", objectName(o), p))
+		}
+	}
+
+	return r, nil
+}
+
+func APIsAreAvailableInKubeVersionRange(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if c.Metadata.KubeVersion == "" {
+		return Result{Reason: MinKuberVersionNotSpecified}, nil
+	}
+	constraint, err := ParseKubeVersion(c.Metadata.KubeVersion)
+	if err != nil {
+		return Result{Reason: KubeVersionIsNotValidPrefix + err.Error()}, nil
+	}
+	if IsNewerThanKnownKubeVersions(constraint) {
+		// the availability of the APIs is only known up to the catalog's version
+		return Result{
+			Ok:      true,
+			Reason:  KubeAPIsAreNotKnown,
+			Details: []string{"the latest known Kubernetes version is " + DefaultKubeAPICatalog.KubeVersion},
+		}, nil
+	}
+	versions := DefaultKubeAPICatalog.kubeMinorVersions(constraint)
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: KubeAPIsAreAvailable}
+	for _, o := range objects {
+		unavailable, deprecated := DefaultKubeAPICatalog.kubeAPIProblems(o, versions)
+		if unavailable != "" {
+			r.Ok = false
+			r.Reason = KubeAPIsAreNotAvailable
+			r.Details = append(r.Details, fmt.Sprintf("%s %s", objectName(o), unavailable))
+		}
+		if deprecated != "" {
+			r.Details = append(r.Details, fmt.Sprintf("%s %s", objectName(o), deprecated))
+		}
+	}
+
+	return r, nil
+}
+
+func HasCompleteMetadata(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return Result{}, err
+	}
+
+	annotations := DefaultRequiredAnnotations
+	if opts.Config != nil && opts.Config.IsSet(RequiredAnnotationsConfigKey) {
+		annotations = opts.Config.GetStringSlice(RequiredAnnotationsConfigKey)
+	}
+
+	problems, err := chartMetadataProblems(c, annotations)
+	if err != nil {
+		return Result{}, err
+	}
+
+	r := Result{Ok: true, Reason: ChartMetadataIsComplete}
+	if len(problems) > 0 {
+		r.Ok = false
+		r.Reason = ChartMetadataIsNotComplete
+		r.Details = problems
+	}
+
+	return r, nil
+}
+
+func DependenciesAreLockedAndVendored(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if len(c.Metadata.Dependencies) == 0 && c.Lock == nil {
+		return Result{Ok: true, Reason: ChartHasNoDependencies}, nil
+	}
+
+	r := Result{Ok: true, Reason: DependenciesLockedAndVendored}
+	if problems := dependencyProblems(c); len(problems) > 0 {
+		r.Ok = false
+		r.Reason = DependenciesNotLockedAndVendored
+		r.Details = problems
+	}
+
+	return r, nil
+}
+
+func ContainersHaveProbes(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	objects, err := renderManifests(opts)
+	if err != nil {
+		return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ProbesAreDefined}
+	for _, o := range objects {
+		if !longRunningKinds[o.GetKind()] {
+			continue
+		}
+		spec, _, err := podSpecOf(o)
+		if err != nil {
+			return Result{}, err
+		}
+		for _, p := range podSpecProbeProblems(spec) {
+			r.Ok = false
+			r.Reason = ProbesAreNotDefined
+			r.Details = append(r.Details, fmt.Sprintf("%s %s", objectName(o), p))
+		}
+	}
+
+	return r, nil
+}
+
+func HasVerifiedProvenance(opts *CheckOptions) (Result, error) {
+	if _, _, err := LoadChartFromURI(opts.URI); err != nil {
+		return Result{}, err
+	}
+
+	archive, prov, cleanup, ok, err := chartProvenance(opts.URI)
+	defer cleanup()
+	if err != nil {
+		return Result{}, err
+	}
+	if !ok {
+		return Result{Reason: ProvenanceIsNotFound}, nil
+	}
+
+	keyring := defaultKeyring()
+	if opts.Config != nil && opts.Config.IsSet(ProvenanceKeyringConfigKey) {
+		keyring = opts.Config.GetString(ProvenanceKeyringConfigKey)
+	}
+
+	signatory, err := provenance.NewFromKeyring(keyring, "")
+	if err != nil {
+		return Result{Reason: ProvenanceIsNotVerifiedPrefix + "reading keyring: " + err.Error()}, nil
+	}
+
+	// the signer is known before the digest is compared, so a mismatch still reports who signed the chart
+	v, err := signatory.Verify(archive, prov)
+	if v == nil || v.SignedBy == nil {
+		return Result{Reason: ProvenanceIsNotVerifiedPrefix + err.Error()}, nil
+	}
+
+	r := Result{Ok: true, Reason: ProvenanceIsVerified, Details: []string{"signed by " + signerIdentity(v.SignedBy)}}
+	switch {
+	case err != nil && isDigestMismatch(err):
+		r.Ok = false
+		r.Reason = ProvenanceDigestDoesNotMatch
+		r.Details = append(r.Details, err.Error())
+	case err != nil:
+		// such as provenance files holding no digest for the archive
+		r.Ok = false
+		r.Reason = ProvenanceIsNotVerifiedPrefix + err.Error()
+	default:
+		r.Details = append(r.Details, "archive digest "+v.FileHash+" matches the signed digest")
+	}
+
+	return r, nil
 }
@@ -19,7 +19,10 @@ package checks
 import (
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestIsHelmV3(t *testing.T) {
@@ -34,7 +37,7 @@ func TestIsHelmV3(t *testing.T) {
 
 	for _, tc := range positiveTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := IsHelmV3(tc.uri)
+			r, err := IsHelmV3(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.True(t, r.Ok)
@@ -48,7 +51,7 @@ func TestIsHelmV3(t *testing.T) {
 
 	for _, tc := range negativeTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := IsHelmV3(tc.uri)
+			r, err := IsHelmV3(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.False(t, r.Ok)
@@ -69,7 +72,7 @@ func TestHasReadme(t *testing.T) {
 
 	for _, tc := range positiveTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := HasReadme(tc.uri)
+			r, err := HasReadme(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.True(t, r.Ok)
@@ -83,7 +86,7 @@ func TestHasReadme(t *testing.T) {
 
 	for _, tc := range negativeTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := HasReadme(tc.uri)
+			r, err := HasReadme(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.False(t, r.Ok)
@@ -96,33 +99,53 @@ func TestContainsTest(t *testing.T) {
 	type testCase struct {
 		description string
 		uri         string
+		details     []string
 	}
 
 	positiveTestCases := []testCase{
-		{description: "tarball contains at least one test", uri: "chart-0.1.0-v3.valid.tgz"},
+		{
+			description: "tarball contains at least one test",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			details:     []string{"Pod/RELEASE-NAME-chart-test-connection"},
+		},
+		{
+			description: "legacy test hook outside the tests directory",
+			uri:         "chart-0.1.0-v3.test-hook-outside-tests.tgz",
+			details:     []string{"Job/RELEASE-NAME-chart-smoke-test"},
+		},
 	}
 
 	for _, tc := range positiveTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := ContainsTest(tc.uri)
+			r, err := ContainsTest(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.True(t, r.Ok)
 			require.Equal(t, ChartTestFilesExist, r.Reason)
+			require.Equal(t, tc.details, r.Details)
 		})
 	}
 
 	negativeTestCases := []testCase{
 		{description: "tarball contains at least one test", uri: "chart-0.1.0-v3.valid.notest.tgz"},
+		{
+			description: "tests directory without runnable test hooks",
+			uri:         "chart-0.1.0-v3.test-not-hook.tgz",
+			details: []string{
+				"ConfigMap/RELEASE-NAME-chart-test-config is annotated as a test hook but is neither a Pod nor a Job",
+				"Pod/RELEASE-NAME-chart-test-empty is annotated as a test hook but has no containers",
+			},
+		},
 	}
 
 	for _, tc := range negativeTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := ContainsTest(tc.uri)
+			r, err := ContainsTest(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.False(t, r.Ok)
 			require.Equal(t, ChartTestFilesDoesNotExist, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
 		})
 	}
 }
@@ -139,7 +162,7 @@ func TestHasValuesSchema(t *testing.T) {
 
 	for _, tc := range positiveTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := ContainsValuesSchema(tc.uri)
+			r, err := ContainsValuesSchema(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.True(t, r.Ok)
@@ -153,7 +176,7 @@ func TestHasValuesSchema(t *testing.T) {
 
 	for _, tc := range negativeTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := ContainsValuesSchema(tc.uri)
+			r, err := ContainsValuesSchema(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.False(t, r.Ok)
@@ -174,7 +197,7 @@ func TestHasValues(t *testing.T) {
 
 	for _, tc := range positiveTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := ContainsValues(tc.uri)
+			r, err := ContainsValues(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.True(t, r.Ok)
@@ -188,7 +211,7 @@ func TestHasValues(t *testing.T) {
 
 	for _, tc := range negativeTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := ContainsValues(tc.uri)
+			r, err := ContainsValues(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.False(t, r.Ok)
@@ -201,33 +224,68 @@ func TestHasMinKubeVersion(t *testing.T) {
 	type testCase struct {
 		description string
 		uri         string
+		reason      string
 	}
 
 	positiveTestCases := []testCase{
-		{description: "minimum Kubernetes version specified", uri: "chart-0.1.0-v3.valid.tgz"},
+		{
+			description: "minimum Kubernetes version specified",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			reason:      MinKuberVersionSpecified,
+		},
+		{
+			description: "minimum Kubernetes version newer than the known ones",
+			uri:         "chart-0.1.0-v3.kube-version-newer.tgz",
+			reason:      MinKubeVersionIsNewerThanKnown,
+		},
 	}
 
 	for _, tc := range positiveTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := HasMinKubeVersion(tc.uri)
+			r, err := HasMinKubeVersion(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.True(t, r.Ok)
-			require.Equal(t, MinKuberVersionSpecified, r.Reason)
+			require.Equal(t, tc.reason, r.Reason)
 		})
 	}
 
-	negativeTestCases := []testCase{
-		{description: "minimum Kubernetes version not specified", uri: "chart-0.1.0-v3.without-minkubeversion.tgz"},
+	type negativeTestCase struct {
+		description string
+		uri         string
+		reason      string
+	}
+
+	negativeTestCases := []negativeTestCase{
+		{
+			description: "minimum Kubernetes version not specified",
+			uri:         "chart-0.1.0-v3.without-minkubeversion.tgz",
+			reason:      MinKuberVersionNotSpecified,
+		},
+		{
+			description: "minimum Kubernetes version not being a valid constraint",
+			uri:         "chart-0.1.0-v3.kube-version-invalid.tgz",
+			reason:      KubeVersionIsNotValidPrefix + "improper constraint: >= one.twenty",
+		},
+		{
+			description: "Kubernetes version constraint without lower bound",
+			uri:         "chart-0.1.0-v3.kube-version-unbounded.tgz",
+			reason:      KubeVersionIsNotValidPrefix + ErrKubeVersionWithoutLowerBound.Error(),
+		},
+		{
+			description: "Kubernetes version constraint nothing satisfies",
+			uri:         "chart-0.1.0-v3.kube-version-unsatisfiable.tgz",
+			reason:      KubeVersionIsNotValidPrefix + ErrKubeVersionNotSatisfiable.Error(),
+		},
 	}
 
 	for _, tc := range negativeTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := HasMinKubeVersion(tc.uri)
+			r, err := HasMinKubeVersion(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.False(t, r.Ok)
-			require.Equal(t, MinKuberVersionNotSpecified, r.Reason)
+			require.Equal(t, tc.reason, r.Reason)
 		})
 	}
 
@@ -245,7 +303,7 @@ func TestNotContainCRDs(t *testing.T) {
 
 	for _, tc := range positiveTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := NotContainCRDs(tc.uri)
+			r, err := NotContainCRDs(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.True(t, r.Ok)
@@ -259,7 +317,7 @@ func TestNotContainCRDs(t *testing.T) {
 
 	for _, tc := range negativeTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := NotContainCRDs(tc.uri)
+			r, err := NotContainCRDs(&CheckOptions{URI: tc.uri})
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.False(t, r.Ok)
@@ -272,34 +330,1307 @@ func TestHelmLint(t *testing.T) {
 	type testCase struct {
 		description string
 		uri         string
+		config      map[string]interface{}
+		details     []string
+	}
+
+	const (
+		iconMessage   = "[INFO] Chart.yaml: icon is recommended"
+		indentMessage = `[WARNING] templates/labels.yaml: document starts with an illegal indent: "  apiVersion: v1", which may cause parsing problems`
+	)
+	lintValues := []string{"chart-0.1.0-v3.lint-config.values.yaml"}
+
+	run := func(tc testCase) (Result, error) {
+		opts := &CheckOptions{URI: tc.uri}
+		if tc.config != nil {
+			opts.Config = viper.New()
+			for k, v := range tc.config {
+				opts.Config.Set(k, v)
+			}
+		}
+		return HelmLint(opts)
 	}
 
 	positiveTestCases := []testCase{
 		{description: "Helm lint works for valid chart", uri: "chart-0.1.0-v3.valid.tgz"},
+		{
+			description: "Helm lint info messages don't fail by default",
+			uri:         "chart-0.1.0-v2.invalid.tgz",
+			details:     []string{iconMessage},
+		},
+		{
+			description: "Helm lint warnings don't fail without strict mode",
+			uri:         "chart-0.1.0-v3.lint-config.tgz",
+			config: map[string]interface{}{
+				HelmLintValuesConfigKey:    lintValues,
+				HelmLintNamespaceConfigKey: "charts",
+			},
+			details: []string{iconMessage, indentMessage},
+		},
+		{
+			description: "Helm lint warnings don't fail in strict mode when the failing severity is error",
+			uri:         "chart-0.1.0-v3.lint-config.tgz",
+			config: map[string]interface{}{
+				HelmLintValuesConfigKey:    lintValues,
+				HelmLintNamespaceConfigKey: "charts",
+				HelmLintStrictConfigKey:    true,
+				HelmLintSeverityConfigKey:  "error",
+			},
+			details: []string{iconMessage, indentMessage},
+		},
 	}
 
 	for _, tc := range positiveTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := HelmLint(tc.uri)
+			r, err := run(tc)
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.True(t, r.Ok)
 			require.Equal(t, HelmLintSuccessful, r.Reason)
+			require.Equal(t, tc.details, r.Details)
 		})
 	}
 
 	negativeTestCases := []testCase{
-		{description: "Helm lint fails for invalid chart", uri: "chart-0.1.0-v2.invalid.tgz"},
+		{
+			description: "Helm lint fails for invalid chart when the failing severity is info",
+			uri:         "chart-0.1.0-v2.invalid.tgz",
+			config:      map[string]interface{}{HelmLintSeverityConfigKey: "info"},
+			details:     []string{iconMessage},
+		},
+		{
+			description: "Helm lint fails without the values the chart requires",
+			uri:         "chart-0.1.0-v3.lint-config.tgz",
+			details: []string{
+				iconMessage,
+				`[ERROR] templates/: template: chart/templates/configmap.yaml:2:4: executing "chart/templates/configmap.yaml" at <fail "host is required">: error calling fail: host is required`,
+			},
+		},
+		{
+			description: "Helm lint fails in the namespace the chart rejects",
+			uri:         "chart-0.1.0-v3.lint-config.tgz",
+			config:      map[string]interface{}{HelmLintValuesConfigKey: lintValues},
+			details: []string{
+				iconMessage,
+				`[ERROR] templates/: template: chart/templates/configmap.yaml:5:4: executing "chart/templates/configmap.yaml" at <fail "the chart must not be installed in the default namespace">: error calling fail: the chart must not be installed in the default namespace`,
+			},
+		},
+		{
+			description: "Helm lint warnings fail in strict mode",
+			uri:         "chart-0.1.0-v3.lint-config.tgz",
+			config: map[string]interface{}{
+				HelmLintValuesConfigKey:    lintValues,
+				HelmLintNamespaceConfigKey: "charts",
+				HelmLintStrictConfigKey:    true,
+			},
+			details: []string{iconMessage, indentMessage},
+		},
 	}
 
 	for _, tc := range negativeTestCases {
 		t.Run(tc.description, func(t *testing.T) {
-			r, err := HelmLint(tc.uri)
+			r, err := run(tc)
 			require.NoError(t, err)
 			require.NotNil(t, r)
 			require.False(t, r.Ok)
 			require.Contains(t, r.Reason, HelmLintHasFailedPrefix)
+			require.Equal(t, tc.details, r.Details)
+		})
+	}
+
+	t.Run("Helm lint with unknown failing severity", func(t *testing.T) {
+		_, err := run(testCase{
+			uri:    "chart-0.1.0-v3.valid.tgz",
+			config: map[string]interface{}{HelmLintSeverityConfigKey: "fatal"},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestKeywordsAreOpenshiftCategories(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "keywords mapped to OpenShift categories", uri: "chart-0.1.0-v3.openshift-categories.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := KeywordsAreOpenshiftCategories(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, KeywordsMapToCategories, r.Reason)
+			require.Contains(t, r.Details, `keyword "database" matches category "Databases"`)
+			require.Contains(t, r.Details,
+				`keyword "postgress" does not match any category, closest categories: Databases/Postgres`)
+			require.Contains(t, r.Details, `keyword "web" does not match any category`)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "no keywords", uri: "chart-0.1.0-v3.valid.tgz"},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := KeywordsAreOpenshiftCategories(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, KeywordsDoNotMapToCategories, r.Reason)
+		})
+	}
+}
+
+func TestIsCommercialChart(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart with provider annotations and certified images", uri: "chart-0.1.0-v3.commercial.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := IsCommercialChart(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ChartIsCommercial, r.Reason)
+			require.Contains(t, r.Details,
+				`image "registry.connect.redhat.com/example/nginx" is served by certified registry "registry.connect.redhat.com"`)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart without provider annotations", uri: "chart-0.1.0-v3.valid.tgz"},
+		{description: "chart with only the required annotations", uri: "chart-0.1.0-v3.community-annotated.tgz"},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := IsCommercialChart(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ChartIsNotCommercial, r.Reason)
+		})
+	}
+}
+
+func TestIsCommunityChart(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart without provider annotations", uri: "chart-0.1.0-v3.valid.tgz"},
+		{description: "chart with only the required annotations", uri: "chart-0.1.0-v3.community-annotated.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := IsCommunityChart(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ChartIsCommunity, r.Reason)
+			require.Contains(t, r.Details, `image "nginx" is served by public registry "docker.io"`)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "chart with provider annotations and certified images", uri: "chart-0.1.0-v3.commercial.tgz"},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := IsCommunityChart(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ChartIsNotCommunity, r.Reason)
+		})
+	}
+}
+
+func TestNotContainsInfraPluginsAndDrivers(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "Not contain infra plugins and drivers", uri: "chart-0.1.0-v3.valid.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NotContainsInfraPluginsAndDrivers(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ChartDoesNotContainInfra, r.Reason)
+			require.Empty(t, r.Details)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "Contain infra plugins and drivers", uri: "chart-0.1.0-v3.with-infra-plugins.tgz"},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NotContainsInfraPluginsAndDrivers(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ChartContainsInfraPlugins, r.Reason)
+			require.ElementsMatch(t, []string{
+				"DaemonSet/RELEASE-NAME-chart-device-plugin: device plugin mounting host path /var/lib/kubelet/device-plugins",
+				"DaemonSet/RELEASE-NAME-chart-node-agent: privileged node agent mounting host paths /",
+				"CSIDriver/RELEASE-NAME-chart.example.com: CSI driver",
+				"StorageClass/RELEASE-NAME-chart-fast: storage class",
+			}, r.Details)
+		})
+	}
+}
+
+func TestCanBeInstalledWithoutClusterAdminPrivileges(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "Not contain cluster-scoped objects", uri: "chart-0.1.0-v3.valid.tgz"},
+		{
+			description: "Cluster-scoped objects of disabled dependencies are not rendered",
+			uri:         "chart-0.1.0-v3.dependencies-disabled.tgz",
+		},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := CanBeInstalledWithoutClusterAdminPrivileges(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ChartDoesNotRequireClusterAdminPrivileges, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{description: "Contain cluster-scoped objects", uri: "chart-0.1.0-v3.with-cluster-scoped.tgz"},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := CanBeInstalledWithoutClusterAdminPrivileges(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ChartRequiresClusterAdminPrivileges, r.Reason)
+			require.ElementsMatch(t, []string{
+				"CustomResourceDefinition/backends.example.com is cluster-scoped",
+				"ClusterRole/RELEASE-NAME-chart is cluster-scoped",
+				"ClusterRoleBinding/RELEASE-NAME-chart is cluster-scoped",
+				"PriorityClass/RELEASE-NAME-chart-critical is cluster-scoped",
+			}, r.Details)
+		})
+	}
+}
+
+func TestCanBeInstalledWithoutManualPreRequisites(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "Not require manual prerequisites", uri: "chart-0.1.0-v3.valid.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := CanBeInstalledWithoutManualPreRequisites(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ChartDoesNotRequireManualPrerequisites, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "Require manual prerequisites",
+			uri:         "chart-0.1.0-v3.with-prerequisites.tgz",
+			details: []string{
+				"template chart/templates/database.yaml requires a value without default: " +
+					"execution error at (chart/templates/database.yaml:7:19): A database host is required",
+				"Secret/registry-credentials referenced by Deployment/RELEASE-NAME-chart is not created by the chart",
+				"ServiceAccount/worker referenced by StatefulSet/RELEASE-NAME-chart-worker is not created by the chart",
+				"Secret/db-credentials referenced by StatefulSet/RELEASE-NAME-chart-worker is not created by the chart",
+				"PersistentVolumeClaim/worker-data referenced by StatefulSet/RELEASE-NAME-chart-worker is not created by the chart",
+			},
+		},
+		{
+			description: "Fail with the default values",
+			uri:         "chart-0.1.0-v3.with-fail-guard.tgz",
+			details: []string{
+				"template chart/templates/license.yaml fails with the default values: " +
+					"the license must be accepted by setting acceptLicense",
+			},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := CanBeInstalledWithoutManualPreRequisites(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ChartRequiresManualPrerequisites, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestReadmeContainsValuesSchema(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reason      string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "README documents all values", uri: "chart-0.1.0-v3.readme-values.tgz"},
+		{
+			description: "README documents all values after a maintainers table",
+			uri:         "chart-0.1.0-v3.readme-values-maintainers.tgz",
+		},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ReadmeContainsValuesSchema(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ReadmeDocumentsValues, r.Reason)
+			require.Empty(t, r.Details)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "README without values table",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			reason:      ReadmeDoesNotContainValuesTable,
+		},
+		{
+			description: "chart without README",
+			uri:         "chart-0.1.0-v3.without-readme.tgz",
+			reason:      ReadmeDoesNotExist,
+		},
+		{
+			description: "README with undocumented and stale values",
+			uri:         "chart-0.1.0-v3.readme-values-stale.tgz",
+			reason:      ReadmeDoesNotDocumentValues,
+			details: []string{
+				`value "autoscaling.enabled" is not documented in README`,
+				`value "autoscaling.maxReplicas" is not documented in README`,
+				`value "autoscaling.minReplicas" is not documented in README`,
+				`value "autoscaling.targetCPUUtilizationPercentage" is not documented in README`,
+				`value "port" is not documented in README`,
+				`README documents "database.host", which does not exist in values`,
+			},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ReadmeContainsValuesSchema(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+			require.Equal(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestImagesArePinnedByDigest(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "images pinned by digest", uri: "chart-0.1.0-v3.images-pinned.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ImagesArePinnedByDigest(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ImagesPinnedByDigest, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "images pinned by tag",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			details: []string{
+				`Deployment/RELEASE-NAME-chart container "chart": image "nginx:1.16.0" is pinned by tag instead of digest`,
+				`Pod/RELEASE-NAME-chart-test-connection container "wget": image "busybox" is not tagged nor pinned by digest`,
+			},
+		},
+		{
+			description: "images using latest tag or untagged",
+			uri:         "chart-0.1.0-v3.images-not-pinned.tgz",
+			details: []string{
+				`CronJob/RELEASE-NAME-chart-cleanup container "wait": image "busybox:latest" uses the mutable latest tag instead of a digest`,
+				`CronJob/RELEASE-NAME-chart-cleanup container "cleanup": image "registry.example.com:5000/example/cleanup" is not tagged nor pinned by digest`,
+				`Deployment/RELEASE-NAME-chart container "chart": image "nginx:1.16.0" is pinned by tag instead of digest`,
+				`Pod/RELEASE-NAME-chart-test-connection container "wget": image "busybox" is not tagged nor pinned by digest`,
+			},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ImagesArePinnedByDigest(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ImagesNotPinnedByDigest, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestImagesAreFromAllowedRegistries(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		registries  []string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{
+			description: "images from configured registries",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			registries:  []string{"docker.io/library"},
+		},
+		{
+			description: "images from configured registries and namespaces",
+			uri:         "chart-0.1.0-v3.commercial.tgz",
+			registries:  []string{"registry.connect.redhat.com/example", "docker.io"},
+		},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := viper.New()
+			config.Set(AllowedRegistriesConfigKey, tc.registries)
+			r, err := ImagesAreFromAllowedRegistries(&CheckOptions{URI: tc.uri, Config: config})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ImagesFromAllowedRegistries, r.Reason)
 		})
 	}
 
+	negativeTestCases := []testCase{
+		{
+			description: "images from public registries with default configuration",
+			uri:         "chart-0.1.0-v3.commercial.tgz",
+			details: []string{
+				`Pod/RELEASE-NAME-chart-test-connection container "wget": image "busybox" is pulled from "docker.io", which is not allowed`,
+			},
+		},
+		{
+			description: "images from registries outside configured namespace",
+			uri:         "chart-0.1.0-v3.images-not-pinned.tgz",
+			registries:  []string{"docker.io/library/nginx", "registry.example.com:5000/other"},
+			details: []string{
+				`CronJob/RELEASE-NAME-chart-cleanup container "wait": image "busybox:latest" is pulled from "docker.io", which is not allowed`,
+				`CronJob/RELEASE-NAME-chart-cleanup container "cleanup": image "registry.example.com:5000/example/cleanup" is pulled from "registry.example.com:5000", which is not allowed`,
+				`Pod/RELEASE-NAME-chart-test-connection container "wget": image "busybox" is pulled from "docker.io", which is not allowed`,
+			},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &CheckOptions{URI: tc.uri}
+			if tc.registries != nil {
+				opts.Config = viper.New()
+				opts.Config.Set(AllowedRegistriesConfigKey, tc.registries)
+			}
+			r, err := ImagesAreFromAllowedRegistries(opts)
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ImagesNotFromAllowedRegistries, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestValuesAreValidAgainstSchema(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reason      string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "default values match the values schema", uri: "chart-0.1.0-v3.valid.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ValuesAreValidAgainstSchema(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ValuesMatchSchema, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "default values violating the values schema",
+			uri:         "chart-0.1.0-v3.values-schema-violations.tgz",
+			reason:      ValuesDoNotMatchSchema,
+			details: []string{
+				"$: name is required",
+				"$.ingress.hosts[0].paths: Array must have at least 1 items",
+				"$.port: Invalid type. Expected: string, given: integer",
+				"$.replicaCount: Must be greater than or equal to 2",
+				"$.image.tag: String length must be greater than or equal to 1",
+			},
+		},
+		{
+			description: "values schema not being a valid JSON Schema",
+			uri:         "chart-0.1.0-v3.values-schema-invalid.tgz",
+			reason:      ValuesSchemaIsNotValid,
+		},
+		{
+			description: "values schema not present",
+			uri:         "chart-0.1.0-v3.no-values-schema.tgz",
+			reason:      ValuesSchemaFileDoesNotExist,
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ValuesAreValidAgainstSchema(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+			if tc.details != nil {
+				require.ElementsMatch(t, tc.details, r.Details)
+			}
+		})
+	}
+}
+
+func TestContainersSetResourceRequestsAndLimits(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "every container sets requests and limits", uri: "chart-0.1.0-v3.resources.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ContainersSetResourceRequestsAndLimits(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ContainersSetResources, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "containers without requests and limits",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			details: []string{
+				`Deployment/RELEASE-NAME-chart: container "chart": missing cpu request, memory request, memory limit`,
+				`Pod/RELEASE-NAME-chart-test-connection: container "wget": missing cpu request, memory request, memory limit`,
+			},
+		},
+		{
+			description: "requests exceeding limits and init container without requests and limits",
+			uri:         "chart-0.1.0-v3.resources-exceeding.tgz",
+			details: []string{
+				`Deployment/RELEASE-NAME-chart: init container "wait": missing cpu request, memory request, memory limit; container "chart": cpu request 500m exceeds limit 100m, memory request 256Mi exceeds limit 128Mi`,
+				`Pod/RELEASE-NAME-chart-test-connection: container "wget": missing memory limit`,
+			},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ContainersSetResourceRequestsAndLimits(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ContainersDoNotSetResources, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestPodsMeetPodSecurityStandards(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		level       string
+		reason      string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{
+			description: "pods meeting the default restricted level",
+			uri:         "chart-0.1.0-v3.pod-security-restricted.tgz",
+			reason:      PodSecurityStandardsMetPrefix + "restricted",
+		},
+		{
+			description: "pods meeting the configured baseline level",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			level:       "baseline",
+			reason:      PodSecurityStandardsMetPrefix + "baseline",
+		},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &CheckOptions{URI: tc.uri}
+			if tc.level != "" {
+				opts.Config = viper.New()
+				opts.Config.Set(PodSecurityLevelConfigKey, tc.level)
+			}
+			r, err := PodsMeetPodSecurityStandards(opts)
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "pods not meeting the default restricted level",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			reason:      PodSecurityStandardsNotMetPrefix + "restricted",
+			details: []string{
+				`Deployment/RELEASE-NAME-chart container "chart": allowPrivilegeEscalation is not false; capabilities do not drop ALL; runAsNonRoot is not true; seccompProfile is not RuntimeDefault or Localhost`,
+				`Pod/RELEASE-NAME-chart-test-connection container "wget": allowPrivilegeEscalation is not false; capabilities do not drop ALL; runAsNonRoot is not true; seccompProfile is not RuntimeDefault or Localhost`,
+			},
+		},
+		{
+			description: "pods using host namespaces and capabilities not meeting the restricted level",
+			uri:         "chart-0.1.0-v3.pod-security-violations.tgz",
+			reason:      PodSecurityStandardsNotMetPrefix + "restricted",
+			details: []string{
+				`Deployment/RELEASE-NAME-chart pod: hostNetwork is true; hostPID is true`,
+				`Deployment/RELEASE-NAME-chart container "chart": capabilities add NET_RAW, SYS_ADMIN; runAsUser is 0; seccompProfile is not RuntimeDefault or Localhost`,
+				`Pod/RELEASE-NAME-chart-test-connection container "wget": capabilities add NET_RAW, SYS_ADMIN; runAsUser is 0; seccompProfile is not RuntimeDefault or Localhost`,
+			},
+		},
+		{
+			description: "pods using host namespaces and capabilities not meeting the baseline level",
+			uri:         "chart-0.1.0-v3.pod-security-violations.tgz",
+			level:       "baseline",
+			reason:      PodSecurityStandardsNotMetPrefix + "baseline",
+			details: []string{
+				`Deployment/RELEASE-NAME-chart pod: hostNetwork is true; hostPID is true`,
+				`Deployment/RELEASE-NAME-chart container "chart": capabilities add NET_RAW, SYS_ADMIN; seccompProfile is Unconfined`,
+				`Pod/RELEASE-NAME-chart-test-connection container "wget": capabilities add NET_RAW, SYS_ADMIN; seccompProfile is Unconfined`,
+			},
+		},
+		{
+			description: "privileged pods mounting host paths not meeting the baseline level",
+			uri:         "chart-0.1.0-v3.with-infra-plugins.tgz",
+			level:       "baseline",
+			reason:      PodSecurityStandardsNotMetPrefix + "baseline",
+			details: []string{
+				`DaemonSet/RELEASE-NAME-chart-device-plugin pod: hostPath volume "device-plugins"`,
+				`DaemonSet/RELEASE-NAME-chart-node-agent pod: hostPath volume "host-root"`,
+				`DaemonSet/RELEASE-NAME-chart-node-agent container "agent": privileged is true`,
+			},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &CheckOptions{URI: tc.uri}
+			if tc.level != "" {
+				opts.Config = viper.New()
+				opts.Config.Set(PodSecurityLevelConfigKey, tc.level)
+			}
+			r, err := PodsMeetPodSecurityStandards(opts)
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+
+	t.Run("unknown configured level", func(t *testing.T) {
+		opts := &CheckOptions{URI: "chart-0.1.0-v3.valid.tgz", Config: viper.New()}
+		opts.Config.Set(PodSecurityLevelConfigKey, "privileged-ish")
+		_, err := PodsMeetPodSecurityStandards(opts)
+		require.Error(t, err)
+	})
+}
+
+func TestIsRestrictedSCCCompatible(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "pods leaving UIDs, groups and SELinux labels to OpenShift", uri: "chart-0.1.0-v3.valid.tgz"},
+		{description: "roles not granting the use of SCCs", uri: "chart-0.1.0-v3.with-cluster-scoped.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := IsRestrictedSCCCompatible(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ChartIsRestrictedSCCCompatible, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "hardcoded UIDs, groups and SELinux labels and SCC use grants",
+			uri:         "chart-0.1.0-v3.scc-incompatible.tgz",
+			details: []string{
+				`Deployment/RELEASE-NAME-chart pod: runAsUser is hardcoded to 1000; ` + runAsUserRemediation,
+				`Deployment/RELEASE-NAME-chart pod: fsGroup is hardcoded to 2000; ` + fsGroupRemediation,
+				`Deployment/RELEASE-NAME-chart container "chart": runAsUser is hardcoded to 1001; ` + runAsUserRemediation,
+				`Deployment/RELEASE-NAME-chart container "chart": seLinuxOptions are hardcoded to level="s0:c123,c456"; ` +
+					seLinuxRemediation,
+				`Role/RELEASE-NAME-chart-anyuid grants the use of SCCs ["anyuid"]; ` + sccUseRemediation,
+			},
+		},
+		{
+			description: "bindings to the cluster roles granting the use of SCCs",
+			uri:         "chart-0.1.0-v3.scc-binding.tgz",
+			details: []string{
+				`RoleBinding/RELEASE-NAME-chart-anyuid grants the use of SCC "anyuid" to ServiceAccount/RELEASE-NAME-chart ` +
+					`through ClusterRole "system:openshift:scc:anyuid"; ` + sccUseRemediation,
+				`ClusterRoleBinding/RELEASE-NAME-chart-privileged grants the use of SCC "privileged" to ` +
+					`ServiceAccount/RELEASE-NAME-chart through ClusterRole "system:openshift:scc:privileged"; ` +
+					sccUseRemediation,
+			},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := IsRestrictedSCCCompatible(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ChartIsNotRestrictedSCCCompatible, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestAPIsAreAvailableInKubeVersionRange(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reason      string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{
+			description: "APIs available at the single kubeVersion",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			reason:      KubeAPIsAreAvailable,
+		},
+		{
+			description: "APIs deprecated but not removed inside the kubeVersion range",
+			uri:         "chart-0.1.0-v3.kube-apis-deprecated.tgz",
+			reason:      KubeAPIsAreAvailable,
+			details: []string{
+				"Ingress/RELEASE-NAME-chart uses networking.k8s.io/v1beta1 Ingress, which is deprecated in Kubernetes 1.19; use networking.k8s.io/v1 instead",
+				"PodDisruptionBudget/RELEASE-NAME-chart uses policy/v1beta1 PodDisruptionBudget, which is deprecated in Kubernetes 1.21; use policy/v1 instead",
+			},
+		},
+		{
+			description: "kubeVersion range newer than the known Kubernetes versions",
+			uri:         "chart-0.1.0-v3.kube-version-newer.tgz",
+			reason:      KubeAPIsAreNotKnown,
+			details:     []string{"the latest known Kubernetes version is 1.29"},
+		},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := APIsAreAvailableInKubeVersionRange(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "APIs removed or not yet introduced inside the kubeVersion range",
+			uri:         "chart-0.1.0-v3.kube-apis-unavailable.tgz",
+			reason:      KubeAPIsAreNotAvailable,
+			details: []string{
+				"CronJob/RELEASE-NAME-chart-cleanup uses batch/v1beta1 CronJob, which is removed in Kubernetes 1.25; use batch/v1 instead",
+				"HorizontalPodAutoscaler/RELEASE-NAME-chart uses autoscaling/v2beta1 HorizontalPodAutoscaler, which is removed in Kubernetes 1.25; use autoscaling/v2 instead",
+				"Ingress/RELEASE-NAME-chart uses networking.k8s.io/v1beta1 Ingress, which is removed in Kubernetes 1.22; use networking.k8s.io/v1 instead",
+				"PodDisruptionBudget/RELEASE-NAME-chart uses policy/v1 PodDisruptionBudget, which is not available before Kubernetes 1.21",
+			},
+		},
+		{
+			description: "kubeVersion not specified",
+			uri:         "chart-0.1.0-v3.without-minkubeversion.tgz",
+			reason:      MinKuberVersionNotSpecified,
+		},
+		{
+			description: "kubeVersion not being a valid constraint",
+			uri:         "chart-0.1.0-v3.kube-version-invalid.tgz",
+			reason:      KubeVersionIsNotValidPrefix + "improper constraint: >= one.twenty",
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := APIsAreAvailableInKubeVersionRange(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestKubeAPIProblems(t *testing.T) {
+	versions := []*semver.Version{semver.MustParse("1.20.0"), semver.MustParse("1.21.0")}
+
+	testCases := []struct {
+		apiVersion  string
+		kind        string
+		unavailable string
+	}{
+		{apiVersion: "v1", kind: "List"},
+		{apiVersion: "v1", kind: "Node"},
+		{apiVersion: "v1", kind: "NotCataloged"},
+		{apiVersion: "apps/v1", kind: "NotCataloged"},
+		{apiVersion: "example.com/v1", kind: "Widget"},
+		{
+			apiVersion:  "apps/v1beta3",
+			kind:        "Deployment",
+			unavailable: "uses apps/v1beta3 Deployment, which is not a known Kubernetes API",
+		},
+		{
+			apiVersion:  "v2",
+			kind:        "ConfigMap",
+			unavailable: "uses v2 ConfigMap, which is not a known Kubernetes API",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.apiVersion+" "+tc.kind, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(tc.apiVersion)
+			obj.SetKind(tc.kind)
+
+			unavailable, deprecated := DefaultKubeAPICatalog.kubeAPIProblems(obj, versions)
+			require.Equal(t, tc.unavailable, unavailable)
+			require.Empty(t, deprecated)
+		})
+	}
+}
+
+func TestSupportedOpenshiftVersions(t *testing.T) {
+	testCases := []struct {
+		kubeVersion string
+		expected    []string
+	}{
+		{kubeVersion: "1.20.0", expected: []string{"4.7"}},
+		{kubeVersion: ">=1.19.0-0 <1.22.0-0", expected: []string{"4.6", "4.7", "4.8"}},
+		{kubeVersion: "~1.21.3", expected: []string{"4.8"}},
+		{kubeVersion: ">=1.27", expected: []string{"4.14", "4.15", "4.16"}},
+		{kubeVersion: "1.15.x", expected: []string{}},
+		{kubeVersion: "=1.21.4", expected: []string{"4.8"}},
+		{kubeVersion: ">=1.20.3 <1.20.9", expected: []string{"4.7"}},
+		{kubeVersion: ">1.20.3 <1.20.5", expected: []string{"4.7"}},
+		{kubeVersion: "1.19.2 - 1.21.1", expected: []string{"4.6", "4.7", "4.8"}},
+		{kubeVersion: ">1.29", expected: []string{}},
+		{kubeVersion: ">=1.30.0", expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.kubeVersion, func(t *testing.T) {
+			versions, err := SupportedOpenshiftVersions(tc.kubeVersion)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, versions)
+		})
+	}
+
+	for _, kubeVersion := range []string{"", "<1.22", ">=1.22 <1.20", ">1.20.3 <1.20.4", "latest"} {
+		t.Run("invalid "+kubeVersion, func(t *testing.T) {
+			_, err := SupportedOpenshiftVersions(kubeVersion)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseKubeVersion(t *testing.T) {
+	testCases := []struct {
+		kubeVersion string
+		err         error
+		newer       bool
+	}{
+		{kubeVersion: ">=1.20.0"},
+		{kubeVersion: "~1.21.3"},
+		{kubeVersion: ">=1.28 <1.31"},
+		{kubeVersion: ">1.29", newer: true},
+		{kubeVersion: ">=1.30.0", newer: true},
+		{kubeVersion: ">=2", newer: true},
+		{kubeVersion: "<1.22", err: ErrKubeVersionWithoutLowerBound},
+		{kubeVersion: ">=0.1", err: ErrKubeVersionWithoutLowerBound},
+		{kubeVersion: "1.x", err: ErrKubeVersionWithoutLowerBound},
+		{kubeVersion: ">=1.0.0", err: ErrKubeVersionWithoutLowerBound},
+		{kubeVersion: ">=1.22 <1.20", err: ErrKubeVersionNotSatisfiable},
+		{kubeVersion: ">1.20.3 <1.20.4", err: ErrKubeVersionNotSatisfiable},
+		{kubeVersion: ">=1.31 <1.30", err: ErrKubeVersionNotSatisfiable},
+		{kubeVersion: ">=0.5 <1.0.0", err: ErrKubeVersionNotSatisfiable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.kubeVersion, func(t *testing.T) {
+			constraint, err := ParseKubeVersion(tc.kubeVersion)
+			if tc.err != nil {
+				require.Equal(t, tc.err, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.newer, IsNewerThanKnownKubeVersions(constraint))
+		})
+	}
+}
+
+func TestHasCompleteMetadata(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		annotations []string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "complete and well-formed metadata", uri: "chart-0.1.0-v3.metadata-complete.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := HasCompleteMetadata(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ChartMetadataIsComplete, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "missing metadata",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			details: []string{
+				"home is missing",
+				"sources are missing",
+				"maintainers are missing",
+				"appVersion 1.16.0 is not quoted",
+				`annotation "charts.openshift.io/name" is missing`,
+				`annotation "charts.openshift.io/provider" is missing`,
+			},
+		},
+		{
+			description: "malformed metadata",
+			uri:         "chart-0.1.0-v3.metadata-malformed.tgz",
+			details: []string{
+				`home "www.example.com/chart" is not an http or https URL`,
+				`source "git@github.com:example/chart.git" is not an http or https URL`,
+				`maintainer "Example Charts Team" has neither email nor url`,
+				`maintainer "Jane Doe" email "jane.at.example.com" is not valid`,
+				`icon "data:image/png;base64,not-base64!" is neither an http or https URL nor an image data URI`,
+				`version "v0.1.0-metadata-malformed" is not a strict semver version`,
+				`annotation "charts.openshift.io/provider" is missing`,
+				`annotation "charts.openshift.io/supportURL" value "support.example.com" is not an http or https URL`,
+			},
+		},
+		{
+			description: "missing configured annotations",
+			uri:         "chart-0.1.0-v3.metadata-complete.tgz",
+			annotations: []string{ProviderTypeAnnotation},
+			details:     []string{`annotation "charts.openshift.io/providerType" is missing`},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &CheckOptions{URI: tc.uri}
+			if tc.annotations != nil {
+				opts.Config = viper.New()
+				opts.Config.Set(RequiredAnnotationsConfigKey, tc.annotations)
+			}
+			r, err := HasCompleteMetadata(opts)
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ChartMetadataIsNotComplete, r.Reason)
+			require.Equal(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestDependenciesAreLockedAndVendored(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		reason      string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "no dependencies", uri: "chart-0.1.0-v3.valid.tgz", reason: ChartHasNoDependencies},
+		{
+			description: "dependencies pinned, locked and vendored",
+			uri:         "chart-0.1.0-v3.dependencies.tgz",
+			reason:      DependenciesLockedAndVendored,
+		},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := DependenciesAreLockedAndVendored(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "dependencies drifting from Chart.lock, charts/ and values",
+			uri:         "chart-0.1.0-v3.dependencies-drift.tgz",
+			details: []string{
+				"Chart.lock is out of sync with the Chart.yaml dependencies",
+				`dependency "subchart" version "~0.1.0" is not pinned`,
+				`dependency "subchart" is vendored under charts/ at version 0.2.0 instead of 0.1.0`,
+				`dependency "other" is not in Chart.lock`,
+				`dependency "other" is not vendored under charts/`,
+				`dependency "other" condition "other.enabled" is not in values.yaml`,
+				`dependency "other" condition "global.other.enabled" is not in values.yaml`,
+				`dependency "other" tag "frontend" is not in values.yaml`,
+				`Chart.lock dependency "removed" is not in Chart.yaml`,
+			},
+		},
+		{
+			description: "Chart.lock missing",
+			uri:         "chart-0.1.0-v3.dependencies-unlocked.tgz",
+			details:     []string{"Chart.lock is missing"},
+		},
+		{
+			description: "Chart.lock not updated after Chart.yaml dependencies changed",
+			uri:         "chart-0.1.0-v3.dependencies-stale-lock.tgz",
+			details:     []string{"Chart.lock is out of sync with the Chart.yaml dependencies"},
+		},
+		{
+			description: "dependency version missing its patch release",
+			uri:         "chart-0.1.0-v3.dependencies-partial-version.tgz",
+			details:     []string{`dependency "subchart" version "0.1" is not pinned`},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := DependenciesAreLockedAndVendored(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, DependenciesNotLockedAndVendored, r.Reason)
+			require.Equal(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestContainersHaveProbes(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "long-running containers with probes on declared ports", uri: "chart-0.1.0-v3.valid.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ContainersHaveProbes(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.True(t, r.Ok)
+			require.Equal(t, ProbesAreDefined, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "missing probes and probes on undeclared ports",
+			uri:         "chart-0.1.0-v3.probes-missing.tgz",
+			details: []string{
+				`Deployment/RELEASE-NAME-chart container "chart": readiness probe port 8080 matches no container port; liveness probe port "metrics" matches no container port`,
+				`StatefulSet/RELEASE-NAME-chart-db container "db": no liveness probe`,
+			},
+		},
+		{
+			description: "daemon sets without probes",
+			uri:         "chart-0.1.0-v3.with-infra-plugins.tgz",
+			details: []string{
+				`DaemonSet/RELEASE-NAME-chart-device-plugin container "device-plugin": no readiness probe; no liveness probe`,
+				`DaemonSet/RELEASE-NAME-chart-node-agent container "agent": no readiness probe; no liveness probe`,
+			},
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := ContainersHaveProbes(&CheckOptions{URI: tc.uri})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, ProbesAreNotDefined, r.Reason)
+			require.ElementsMatch(t, tc.details, r.Details)
+		})
+	}
+}
+
+func TestHasVerifiedProvenance(t *testing.T) {
+	type testCase struct {
+		description string
+		uri         string
+		keyring     string
+		reason      string
+		details     []string
+	}
+
+	const signer = "Helm Testing (This key should only be used for testing. DO NOT TRUST.) <helm-testing@helm.sh> (key 843BBF981FC18762)"
+
+	config := func(keyring string) *viper.Viper {
+		config := viper.New()
+		config.Set(ProvenanceKeyringConfigKey, keyring)
+		return config
+	}
+
+	t.Run("chart signed by a known publisher", func(t *testing.T) {
+		r, err := HasVerifiedProvenance(&CheckOptions{URI: "chart-0.1.0-v3.signed.tgz", Config: config("provenance-keyring.gpg")})
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		require.True(t, r.Ok)
+		require.Equal(t, ProvenanceIsVerified, r.Reason)
+		require.Equal(t, []string{
+			"signed by " + signer,
+			"archive digest sha256:9291122c81f82af58bb4b8d5150f2b7603c88cd988b251d7653b0d1dcff10fa9 matches the signed digest",
+		}, r.Details)
+	})
+
+	negativeTestCases := []testCase{
+		{
+			description: "chart without provenance file",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			keyring:     "provenance-keyring.gpg",
+			reason:      ProvenanceIsNotFound,
+		},
+		{
+			description: "chart signed by an unknown publisher",
+			uri:         "chart-0.1.0-v3.signed-unknown.tgz",
+			keyring:     "provenance-keyring.gpg",
+			reason:      ProvenanceIsNotVerifiedPrefix + "openpgp: signature made by unknown entity",
+		},
+		{
+			description: "chart modified after being signed",
+			uri:         "chart-0.1.0-v3.signed-tampered.tgz",
+			keyring:     "provenance-keyring.gpg",
+			reason:      ProvenanceDigestDoesNotMatch,
+			details: []string{
+				"signed by " + signer,
+				`sha256 sum does not match for chart-0.1.0-v3.signed-tampered.tgz: "sha256:0b7257164e431097b7f488decd5fe124d27e8003c0a5412e34585d1bea7634fb" != "sha256:7a52e94db5979fa8016e81767ebea717986b7146ea362bb3c3ccd5cf7e4c8e7b"`,
+			},
+		},
+		{
+			description: "provenance file without the archive digest",
+			uri:         "chart-0.1.0-v3.signed-renamed.tgz",
+			keyring:     "provenance-keyring.gpg",
+			reason: ProvenanceIsNotVerifiedPrefix +
+				`provenance does not contain a SHA for a file named "chart-0.1.0-v3.signed-renamed.tgz"`,
+			details: []string{"signed by " + signer},
+		},
+		{
+			description: "missing keyring",
+			uri:         "chart-0.1.0-v3.signed.tgz",
+			keyring:     "non-existing.gpg",
+			reason:      ProvenanceIsNotVerifiedPrefix + "reading keyring: open non-existing.gpg: no such file or directory",
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := HasVerifiedProvenance(&CheckOptions{URI: tc.uri, Config: config(tc.keyring)})
+			require.NoError(t, err)
+			require.NotNil(t, r)
+			require.False(t, r.Ok)
+			require.Equal(t, tc.reason, r.Reason)
+			require.Equal(t, tc.details, r.Details)
+		})
+	}
 }
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// ChartClassification is the catalog tier a chart belongs to.
+type ChartClassification string
+
+const (
+	CommercialChart ChartClassification = "commercial"
+	CommunityChart  ChartClassification = "community"
+
+	OpenshiftAnnotationPrefix = "charts.openshift.io/"
+	NameAnnotation            = OpenshiftAnnotationPrefix + "name"
+	ProviderAnnotation        = OpenshiftAnnotationPrefix + "provider"
+	ProviderTypeAnnotation    = OpenshiftAnnotationPrefix + "providerType"
+	SupportURLAnnotation      = OpenshiftAnnotationPrefix + "supportURL"
+
+	defaultImageRegistry = "docker.io"
+)
+
+// commercialProviderTypes are the values of the providerType annotation indicating a commercial chart.
+var commercialProviderTypes = map[string]bool{"partner": true, "redhat": true}
+
+// certifiedImageRegistries are registries only serving images certified by Red Hat.
+var certifiedImageRegistries = map[string]bool{
+	"registry.redhat.io":              true,
+	"registry.connect.redhat.com":     true,
+	"registry.access.redhat.com":      true,
+	"registry.marketplace.redhat.com": true,
+}
+
+// personalEmailDomains are email domains used by individuals rather than organizations.
+var personalEmailDomains = map[string]bool{
+	"gmail.com":                true,
+	"googlemail.com":           true,
+	"hotmail.com":              true,
+	"outlook.com":              true,
+	"yahoo.com":                true,
+	"protonmail.com":           true,
+	"users.noreply.github.com": true,
+}
+
+// ClassificationResult holds the classification of a chart and the evidence it has been based on.
+type ClassificationResult struct {
+	Classification ChartClassification
+	// Evidence are the findings supporting the classification.
+	Evidence []string
+}
+
+// ClassifyChart classifies the given chart either as commercial or community from its annotations, maintainers and the
+// image registries referenced by its values. An explicit providerType annotation takes precedence over any other
+// evidence; otherwise each finding counts towards one of the classifications, and the chart is considered a community
+// chart unless the commercial evidence outweighs it. The annotations required of every chart, such as the provider
+// one, are not findings.
+func ClassifyChart(c *chart.Chart) ClassificationResult {
+	var (
+		commercial, community int
+		evidence              []string
+	)
+
+	annotations := c.Metadata.Annotations
+	if providerType, ok := annotations[ProviderTypeAnnotation]; ok {
+		classification := CommunityChart
+		if commercialProviderTypes[strings.ToLower(providerType)] {
+			classification = CommercialChart
+		}
+		return ClassificationResult{
+			Classification: classification,
+			Evidence:       []string{fmt.Sprintf("annotation %q is set to %q", ProviderTypeAnnotation, providerType)},
+		}
+	}
+
+	if supportURL := annotations[SupportURLAnnotation]; supportURL != "" {
+		commercial++
+		evidence = append(evidence, fmt.Sprintf("annotation %q offers support at %q", SupportURLAnnotation, supportURL))
+	}
+
+	// the annotations every chart is required to set tell nothing about its classification
+	otherAnnotations := make([]string, 0)
+	for k := range annotations {
+		if strings.HasPrefix(k, OpenshiftAnnotationPrefix) && k != SupportURLAnnotation &&
+			!contains(DefaultRequiredAnnotations, k) {
+			otherAnnotations = append(otherAnnotations, k)
+		}
+	}
+	sort.Strings(otherAnnotations)
+	for _, k := range otherAnnotations {
+		commercial++
+		evidence = append(evidence, fmt.Sprintf("annotation %q is set", k))
+	}
+
+	for _, m := range c.Metadata.Maintainers {
+		if m == nil || m.Email == "" {
+			continue
+		}
+		domain := strings.ToLower(m.Email[strings.LastIndex(m.Email, "@")+1:])
+		if personalEmailDomains[domain] {
+			community++
+			evidence = append(evidence, fmt.Sprintf("maintainer %q uses a personal email address", m.Name))
+		} else {
+			commercial++
+			evidence = append(evidence, fmt.Sprintf("maintainer %q uses an organization email address (%s)", m.Name, domain))
+		}
+	}
+
+	for _, image := range valuesImages(c.Values) {
+		registry := imageRegistry(image)
+		if certifiedImageRegistries[registry] {
+			commercial++
+			evidence = append(evidence, fmt.Sprintf("image %q is served by certified registry %q", image, registry))
+		} else {
+			community++
+			evidence = append(evidence, fmt.Sprintf("image %q is served by public registry %q", image, registry))
+		}
+	}
+
+	classification := CommunityChart
+	if commercial > community {
+		classification = CommercialChart
+	}
+
+	return ClassificationResult{Classification: classification, Evidence: evidence}
+}
+
+// valuesImages extracts the image references found in the given values, either as "image" strings or as "repository"
+// strings optionally qualified by a sibling "registry" key.
+func valuesImages(values map[string]interface{}) []string {
+	var images []string
+	for _, k := range sortedKeys(values) {
+		switch v := values[k].(type) {
+		case string:
+			if k == "image" && v != "" {
+				images = append(images, v)
+			}
+			if k == "repository" && v != "" {
+				if registry, ok := values["registry"].(string); ok && registry != "" {
+					v = registry + "/" + v
+				}
+				images = append(images, v)
+			}
+		case map[string]interface{}:
+			images = append(images, valuesImages(v)...)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					images = append(images, valuesImages(m)...)
+				}
+			}
+		}
+	}
+	return images
+}
+
+// imageRegistry returns the registry hosting the given image reference, following the same rules the container
+// runtimes apply to resolve unqualified references.
+func imageRegistry(image string) string {
+	i := strings.Index(image, "/")
+	if i < 0 {
+		return defaultImageRegistry
+	}
+	host := image[:i]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return defaultImageRegistry
+	}
+	return host
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// clusterScopedKinds are the kinds of cluster-scoped objects, which can't be created by namespace administrators.
+var clusterScopedKinds = map[string]bool{
+	// core
+	"Namespace":        true,
+	"Node":             true,
+	"PersistentVolume": true,
+	"ComponentStatus":  true,
+	// rbac.authorization.k8s.io
+	"ClusterRole":        true,
+	"ClusterRoleBinding": true,
+	// apiextensions.k8s.io, apiregistration.k8s.io
+	"CustomResourceDefinition": true,
+	"APIService":               true,
+	// admissionregistration.k8s.io
+	"MutatingWebhookConfiguration":   true,
+	"ValidatingWebhookConfiguration": true,
+	// scheduling.k8s.io, node.k8s.io
+	"PriorityClass": true,
+	"RuntimeClass":  true,
+	// storage.k8s.io
+	"StorageClass":     true,
+	"CSIDriver":        true,
+	"CSINode":          true,
+	"VolumeAttachment": true,
+	// policy, networking.k8s.io, certificates.k8s.io, flowcontrol.apiserver.k8s.io
+	"PodSecurityPolicy":          true,
+	"IngressClass":               true,
+	"CertificateSigningRequest":  true,
+	"FlowSchema":                 true,
+	"PriorityLevelConfiguration": true,
+	// OpenShift
+	"SecurityContextConstraints": true,
+	"ClusterResourceQuota":       true,
+	"Project":                    true,
+	"ProjectRequest":             true,
+}
+
+// isClusterScoped tells whether the given object is cluster-scoped.
+func isClusterScoped(obj *unstructured.Unstructured) bool {
+	return clusterScopedKinds[obj.GetKind()]
+}
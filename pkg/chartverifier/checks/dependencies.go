@@ -0,0 +1,153 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/provenance"
+)
+
+// tagsValuesKey is the values key holding the tags enabling or disabling dependencies.
+const tagsValuesKey = "tags"
+
+// dependencyProblems returns the problems of the dependencies declared in the Chart.yaml of the given chart: a missing
+// or stale Chart.lock, missing or stale Chart.lock entries, dependencies not vendored under charts/ at their locked
+// version, versions given as ranges, and conditions and tags not present in the chart values.
+func dependencyProblems(c *chart.Chart) []string {
+	problems := make([]string, 0)
+
+	vendored := map[string][]string{}
+	for _, d := range c.Dependencies() {
+		vendored[d.Name()] = append(vendored[d.Name()], d.Metadata.Version)
+	}
+
+	if c.Lock == nil {
+		problems = append(problems, "Chart.lock is missing")
+	} else if digest, err := lockDigest(c.Metadata.Dependencies, c.Lock.Dependencies); err != nil || digest != c.Lock.Digest {
+		problems = append(problems, "Chart.lock is out of sync with the Chart.yaml dependencies")
+	}
+
+	for _, d := range c.Metadata.Dependencies {
+		// partial versions such as 1.2 are ranges of patch releases
+		if _, err := semver.StrictNewVersion(d.Version); err != nil {
+			problems = append(problems, fmt.Sprintf("dependency %q version %q is not pinned", d.Name, d.Version))
+		}
+
+		version := d.Version
+		if c.Lock != nil {
+			locked := lockedDependency(c.Lock, d.Name, d.Repository)
+			switch {
+			case locked == nil:
+				problems = append(problems, fmt.Sprintf("dependency %q is not in Chart.lock", d.Name))
+			case !satisfiesVersion(d.Version, locked.Version):
+				problems = append(problems, fmt.Sprintf("dependency %q is locked at version %s, which doesn't satisfy %q",
+					d.Name, locked.Version, d.Version))
+			default:
+				version = locked.Version
+			}
+		}
+
+		switch versions := vendored[d.Name]; {
+		case len(versions) == 0:
+			problems = append(problems, fmt.Sprintf("dependency %q is not vendored under charts/", d.Name))
+		case !contains(versions, version):
+			problems = append(problems, fmt.Sprintf("dependency %q is vendored under charts/ at version %s instead of %s",
+				d.Name, strings.Join(versions, ", "), version))
+		}
+
+		values := chartutil.Values(c.Values)
+		for _, condition := range strings.Split(d.Condition, ",") {
+			condition = strings.TrimSpace(condition)
+			if condition == "" {
+				continue
+			}
+			if _, err := values.PathValue(condition); err != nil {
+				problems = append(problems, fmt.Sprintf("dependency %q condition %q is not in values.yaml",
+					d.Name, condition))
+			}
+		}
+		for _, tag := range d.Tags {
+			if _, err := values.PathValue(tagsValuesKey + "." + tag); err != nil {
+				problems = append(problems, fmt.Sprintf("dependency %q tag %q is not in values.yaml", d.Name, tag))
+			}
+		}
+	}
+
+	if c.Lock != nil {
+		for _, l := range c.Lock.Dependencies {
+			if !declaresDependency(c.Metadata, l.Name, l.Repository) {
+				problems = append(problems, fmt.Sprintf("Chart.lock dependency %q is not in Chart.yaml", l.Name))
+			}
+		}
+	}
+
+	return problems
+}
+
+// lockDigest returns the digest helm records in Chart.lock for the given Chart.yaml and locked dependencies.
+func lockDigest(dependencies, locked []*chart.Dependency) (string, error) {
+	data, err := json.Marshal([2][]*chart.Dependency{dependencies, locked})
+	if err != nil {
+		return "", err
+	}
+	digest, err := provenance.Digest(bytes.NewBuffer(data))
+	return "sha256:" + digest, err
+}
+
+func lockedDependency(lock *chart.Lock, name, repository string) *chart.Dependency {
+	for _, l := range lock.Dependencies {
+		if l.Name == name && l.Repository == repository {
+			return l
+		}
+	}
+	return nil
+}
+
+func declaresDependency(m *chart.Metadata, name, repository string) bool {
+	for _, d := range m.Dependencies {
+		if d.Name == name && d.Repository == repository {
+			return true
+		}
+	}
+	return false
+}
+
+// satisfiesVersion tells whether version satisfies the given version or range; invalid ones satisfy nothing.
+func satisfiesVersion(constraint, version string) bool {
+	cs, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	v, err := semver.NewVersion(version)
+	return err == nil && cs.Check(v)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
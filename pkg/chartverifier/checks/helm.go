@@ -23,6 +23,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sync"
 
 	"helm.sh/helm/v3/pkg/chartutil"
 
@@ -84,7 +85,10 @@ type ChartCacheItem struct {
 	Path  string
 }
 
+// chartCache holds the charts already loaded, by uri; it is safe for concurrent use, as checks might load charts
+// concurrently.
 type chartCache struct {
+	mu       sync.Mutex
 	chartMap map[string]ChartCacheItem
 }
 
@@ -99,6 +103,9 @@ func (c *chartCache) MakeKey(uri string) string {
 }
 
 func (c *chartCache) Get(uri string) (ChartCacheItem, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if item, ok := c.chartMap[c.MakeKey(uri)]; !ok {
 		return ChartCacheItem{}, false, nil
 	} else {
@@ -112,6 +119,15 @@ func (c *chartCache) Add(uri string, chrt *chart.Chart) (ChartCacheItem, error)
 		return ChartCacheItem{}, err
 	}
 	key := c.MakeKey(uri)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the chart might have been loaded concurrently, in which case the chart already cached is kept
+	if item, ok := c.chartMap[key]; ok {
+		return item, nil
+	}
+
 	cacheDir := path.Join(userCacheDir, "chart-verifier")
 	chartCacheDir := path.Join(cacheDir, key)
 	cacheItem := ChartCacheItem{Chart: chrt, Path: chartCacheDir}
@@ -175,3 +191,16 @@ func IsChartNotFound(err error) bool {
 	_, ok := err.(ChartNotFoundErr)
 	return ok
 }
+
+// readValuesFiles reads and merges the given values files the way helm does, the last one taking precedence.
+func readValuesFiles(files []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for i := len(files) - 1; i >= 0; i-- {
+		vals, err := chartutil.ReadValuesFile(files[i])
+		if err != nil {
+			return nil, err
+		}
+		values = chartutil.CoalesceTables(values, vals)
+	}
+	return values, nil
+}
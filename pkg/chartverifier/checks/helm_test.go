@@ -18,8 +18,11 @@ package checks
 
 import (
 	"context"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/redhat-certification/chart-verifier/pkg/testutil"
@@ -78,3 +81,31 @@ func TestLoadChartFromURI(t *testing.T) {
 
 	cancel()
 }
+
+func TestReadValuesFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.yaml")
+	second := filepath.Join(dir, "second.yaml")
+	require.NoError(t, ioutil.WriteFile(first, []byte("image:\n  tag: \"1.0\"\n  pullPolicy: Always\nreplicaCount: 1\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(second, []byte("image:\n  tag: \"2.0\"\n"), 0644))
+
+	values, err := readValuesFiles([]string{first, second})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"image":        map[string]interface{}{"tag": "2.0", "pullPolicy": "Always"},
+		"replicaCount": float64(1),
+	}, values)
+
+	// lint and render read the same values from the same files
+	config := viper.New()
+	config.Set(RenderValuesConfigKey, []string{first, second})
+	render, err := NewRenderOptions(config)
+	require.NoError(t, err)
+	lint, err := newHelmLintOptions(config)
+	require.NoError(t, err)
+	require.Equal(t, values, render.Values)
+	require.Equal(t, values, lint.Values)
+
+	_, err = readValuesFiles([]string{filepath.Join(dir, "non-existing.yaml")})
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"helm.sh/helm/v3/pkg/lint/support"
+)
+
+const (
+	// HelmLintValuesConfigKey is the key of the helm-lint configuration holding the values files to lint with.
+	HelmLintValuesConfigKey = "values"
+	// HelmLintNamespaceConfigKey is the key of the helm-lint configuration holding the release namespace.
+	HelmLintNamespaceConfigKey = "namespace"
+	// HelmLintStrictConfigKey is the key of the helm-lint configuration enabling strict mode.
+	HelmLintStrictConfigKey = "strict"
+	// HelmLintSeverityConfigKey is the key of the helm-lint configuration holding the severity failing the check.
+	HelmLintSeverityConfigKey = "severity"
+
+	defaultHelmLintNamespace = "default"
+)
+
+// helmLintSeverities maps the names of the lint severities to their values.
+var helmLintSeverities = map[string]int{
+	"info":    support.InfoSev,
+	"warning": support.WarningSev,
+	"error":   support.ErrorSev,
+}
+
+// helmLintOptions are the inputs of a helm lint run.
+type helmLintOptions struct {
+	Values    map[string]interface{}
+	Namespace string
+	Strict    bool
+	// Severity is the lowest severity failing the check: errors, or warnings too in strict mode, as helm lint does,
+	// unless configured otherwise.
+	Severity int
+}
+
+// newHelmLintOptions reads the helm lint options from the given check configuration, which might be nil.
+func newHelmLintOptions(config *viper.Viper) (*helmLintOptions, error) {
+	o := &helmLintOptions{
+		Values:    map[string]interface{}{},
+		Namespace: defaultHelmLintNamespace,
+		Severity:  support.ErrorSev,
+	}
+	if config == nil {
+		return o, nil
+	}
+
+	values, err := readValuesFiles(config.GetStringSlice(HelmLintValuesConfigKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading helm-lint values file")
+	}
+	o.Values = values
+
+	if ns := config.GetString(HelmLintNamespaceConfigKey); ns != "" {
+		o.Namespace = ns
+	}
+
+	if o.Strict = config.GetBool(HelmLintStrictConfigKey); o.Strict {
+		o.Severity = support.WarningSev
+	}
+
+	if name := config.GetString(HelmLintSeverityConfigKey); name != "" {
+		severity, ok := helmLintSeverities[strings.ToLower(name)]
+		if !ok {
+			return nil, errors.Errorf("unknown helm-lint severity %q", name)
+		}
+		o.Severity = severity
+	}
+
+	return o, nil
+}
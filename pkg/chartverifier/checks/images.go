@@ -0,0 +1,95 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"strings"
+)
+
+const (
+	latestTag    = "latest"
+	sha256Prefix = "sha256:"
+	// dockerHubLibrary is the namespace of unqualified Docker Hub images.
+	dockerHubLibrary = "library"
+	// AllowedRegistriesConfigKey is the key of the images-are-from-allowed-registries configuration holding the
+	// allowed registries.
+	AllowedRegistriesConfigKey = "registries"
+)
+
+// DefaultAllowedImageRegistries are the registries images can be pulled from when no registries have been configured.
+var DefaultAllowedImageRegistries = []string{
+	"registry.redhat.io",
+	"registry.access.redhat.com",
+	"registry.connect.redhat.com",
+	"registry.marketplace.redhat.com",
+}
+
+// imageReference is a parsed container image reference.
+type imageReference struct {
+	Registry string
+	// Name is the reference without its tag and digest.
+	Name   string
+	Tag    string
+	Digest string
+}
+
+// parseImageReference splits the given image reference into its registry, name, tag and digest.
+func parseImageReference(image string) imageReference {
+	ref := imageReference{Registry: imageRegistry(image)}
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+	// a colon before the last slash separates the registry host from its port, not the tag
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+	ref.Name = name
+
+	return ref
+}
+
+// IsPinned tells whether the reference is pinned by a sha256 digest.
+func (r imageReference) IsPinned() bool {
+	return strings.HasPrefix(r.Digest, sha256Prefix)
+}
+
+// FullName returns the fully qualified name of the reference, including its registry and the namespace Docker Hub
+// implicitly assigns to unqualified images.
+func (r imageReference) FullName() string {
+	repository := strings.TrimPrefix(r.Name, r.Registry+"/")
+	if r.Registry == defaultImageRegistry && !strings.Contains(repository, "/") {
+		repository = dockerHubLibrary + "/" + repository
+	}
+	return r.Registry + "/" + repository
+}
+
+// IsFromAllowedRegistry tells whether the reference is served by one of the given registries, which can also be
+// qualified by a namespace (e.g. quay.io/organization).
+func (r imageReference) IsFromAllowedRegistry(allowed []string) bool {
+	name := r.FullName()
+	for _, a := range allowed {
+		a = strings.TrimSuffix(strings.TrimSpace(a), "/")
+		if a != "" && (name == a || strings.HasPrefix(name, a+"/")) {
+			return true
+		}
+	}
+	return false
+}
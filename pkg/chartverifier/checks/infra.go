@@ -0,0 +1,85 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// infraHostPaths maps host path prefixes to the kind of infrastructure plugin mounting them.
+var infraHostPaths = []struct {
+	prefix string
+	plugin string
+}{
+	{prefix: "/etc/cni", plugin: "CNI plugin"},
+	{prefix: "/opt/cni", plugin: "CNI plugin"},
+	{prefix: "/var/lib/cni", plugin: "CNI plugin"},
+	{prefix: "/var/lib/kubelet/device-plugins", plugin: "device plugin"},
+	{prefix: "/var/lib/kubelet/plugins", plugin: "CSI node plugin"},
+	{prefix: "/var/lib/kubelet/plugins_registry", plugin: "CSI node plugin"},
+}
+
+// infraPluginReason returns why the given object is considered an infrastructure plugin or driver, or an empty string
+// when it is not.
+func infraPluginReason(obj *unstructured.Unstructured) (string, error) {
+	switch obj.GetKind() {
+	case "CSIDriver":
+		return "CSI driver", nil
+	case "StorageClass":
+		return "storage class", nil
+	case "DaemonSet":
+		return daemonSetPluginReason(obj)
+	}
+	return "", nil
+}
+
+// daemonSetPluginReason inspects the host paths mounted by the given DaemonSet to tell whether it deploys a node level
+// plugin or agent.
+func daemonSetPluginReason(obj *unstructured.Unstructured) (string, error) {
+	spec, _, err := podSpecOf(obj)
+	if err != nil {
+		return "", err
+	}
+
+	hostPaths := make([]string, 0)
+	for _, v := range spec.Volumes {
+		if v.HostPath == nil {
+			continue
+		}
+		for _, p := range infraHostPaths {
+			if strings.HasPrefix(v.HostPath.Path, p.prefix) {
+				return fmt.Sprintf("%s mounting host path %s", p.plugin, v.HostPath.Path), nil
+			}
+		}
+		hostPaths = append(hostPaths, v.HostPath.Path)
+	}
+
+	if len(hostPaths) == 0 {
+		return "", nil
+	}
+
+	for _, c := range append(spec.InitContainers, spec.Containers...) {
+		if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+			return fmt.Sprintf("privileged node agent mounting host paths %s", strings.Join(hostPaths, ", ")), nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,292 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KubeAPI describes when a Kubernetes API group, version and kind was introduced, deprecated and removed, as
+// Kubernetes minor versions; Deprecated and Removed are empty when not applicable.
+type KubeAPI struct {
+	Group      string
+	Version    string
+	Kind       string
+	Introduced string
+	Deprecated string
+	Removed    string
+	// Replacement is the API version replacing a deprecated or removed API, if any.
+	Replacement string
+}
+
+// APIVersion returns the apiVersion objects of the API are declared with.
+func (a KubeAPI) APIVersion() string {
+	return schema.GroupVersion{Group: a.Group, Version: a.Version}.String()
+}
+
+// KubeAPICatalog is the table of Kubernetes APIs availability up to its Kubernetes version.
+type KubeAPICatalog struct {
+	KubeVersion string
+	APIs        []KubeAPI
+}
+
+// DefaultKubeAPICatalog holds the availability of the built-in Kubernetes APIs charts usually create objects of.
+var DefaultKubeAPICatalog = KubeAPICatalog{
+	KubeVersion: "1.29",
+	APIs: []KubeAPI{
+		{Version: "v1", Kind: "Binding", Introduced: "1.0"},
+		{Version: "v1", Kind: "ComponentStatus", Introduced: "1.0", Deprecated: "1.19"},
+		{Version: "v1", Kind: "ConfigMap", Introduced: "1.0"},
+		{Version: "v1", Kind: "Endpoints", Introduced: "1.0"},
+		{Version: "v1", Kind: "Event", Introduced: "1.0"},
+		{Version: "v1", Kind: "LimitRange", Introduced: "1.0"},
+		{Version: "v1", Kind: "List", Introduced: "1.0"},
+		{Version: "v1", Kind: "Namespace", Introduced: "1.0"},
+		{Version: "v1", Kind: "Node", Introduced: "1.0"},
+		{Version: "v1", Kind: "PersistentVolume", Introduced: "1.0"},
+		{Version: "v1", Kind: "PersistentVolumeClaim", Introduced: "1.0"},
+		{Version: "v1", Kind: "Pod", Introduced: "1.0"},
+		{Version: "v1", Kind: "PodTemplate", Introduced: "1.0"},
+		{Version: "v1", Kind: "ReplicationController", Introduced: "1.0"},
+		{Version: "v1", Kind: "ResourceQuota", Introduced: "1.0"},
+		{Version: "v1", Kind: "Secret", Introduced: "1.0"},
+		{Version: "v1", Kind: "Service", Introduced: "1.0"},
+		{Version: "v1", Kind: "ServiceAccount", Introduced: "1.0"},
+
+		{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "MutatingWebhookConfiguration", Introduced: "1.16"},
+		{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration", Introduced: "1.16"},
+		{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "MutatingWebhookConfiguration", Introduced: "1.9", Deprecated: "1.16", Removed: "1.22", Replacement: "admissionregistration.k8s.io/v1"},
+		{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingWebhookConfiguration", Introduced: "1.9", Deprecated: "1.16", Removed: "1.22", Replacement: "admissionregistration.k8s.io/v1"},
+
+		{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition", Introduced: "1.16"},
+		{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition", Introduced: "1.7", Deprecated: "1.16", Removed: "1.22", Replacement: "apiextensions.k8s.io/v1"},
+
+		{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService", Introduced: "1.10"},
+		{Group: "apiregistration.k8s.io", Version: "v1beta1", Kind: "APIService", Introduced: "1.7", Deprecated: "1.19", Removed: "1.22", Replacement: "apiregistration.k8s.io/v1"},
+
+		{Group: "apps", Version: "v1", Kind: "ControllerRevision", Introduced: "1.9"},
+		{Group: "apps", Version: "v1", Kind: "DaemonSet", Introduced: "1.9"},
+		{Group: "apps", Version: "v1", Kind: "Deployment", Introduced: "1.9"},
+		{Group: "apps", Version: "v1", Kind: "ReplicaSet", Introduced: "1.9"},
+		{Group: "apps", Version: "v1", Kind: "StatefulSet", Introduced: "1.9"},
+		{Group: "apps", Version: "v1beta1", Kind: "Deployment", Introduced: "1.6", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+		{Group: "apps", Version: "v1beta1", Kind: "StatefulSet", Introduced: "1.5", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+		{Group: "apps", Version: "v1beta2", Kind: "DaemonSet", Introduced: "1.8", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+		{Group: "apps", Version: "v1beta2", Kind: "Deployment", Introduced: "1.8", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+		{Group: "apps", Version: "v1beta2", Kind: "ReplicaSet", Introduced: "1.8", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+		{Group: "apps", Version: "v1beta2", Kind: "StatefulSet", Introduced: "1.8", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+
+		{Group: "autoscaling", Version: "v1", Kind: "HorizontalPodAutoscaler", Introduced: "1.2"},
+		{Group: "autoscaling", Version: "v2", Kind: "HorizontalPodAutoscaler", Introduced: "1.23"},
+		{Group: "autoscaling", Version: "v2beta1", Kind: "HorizontalPodAutoscaler", Introduced: "1.8", Deprecated: "1.22", Removed: "1.25", Replacement: "autoscaling/v2"},
+		{Group: "autoscaling", Version: "v2beta2", Kind: "HorizontalPodAutoscaler", Introduced: "1.12", Deprecated: "1.23", Removed: "1.26", Replacement: "autoscaling/v2"},
+
+		{Group: "batch", Version: "v1", Kind: "CronJob", Introduced: "1.21"},
+		{Group: "batch", Version: "v1", Kind: "Job", Introduced: "1.2"},
+		{Group: "batch", Version: "v1beta1", Kind: "CronJob", Introduced: "1.8", Deprecated: "1.21", Removed: "1.25", Replacement: "batch/v1"},
+
+		{Group: "certificates.k8s.io", Version: "v1", Kind: "CertificateSigningRequest", Introduced: "1.19"},
+		{Group: "certificates.k8s.io", Version: "v1beta1", Kind: "CertificateSigningRequest", Introduced: "1.4", Deprecated: "1.19", Removed: "1.22", Replacement: "certificates.k8s.io/v1"},
+
+		{Group: "coordination.k8s.io", Version: "v1", Kind: "Lease", Introduced: "1.14"},
+		{Group: "coordination.k8s.io", Version: "v1beta1", Kind: "Lease", Introduced: "1.12", Deprecated: "1.14", Removed: "1.22", Replacement: "coordination.k8s.io/v1"},
+
+		{Group: "discovery.k8s.io", Version: "v1", Kind: "EndpointSlice", Introduced: "1.21"},
+		{Group: "discovery.k8s.io", Version: "v1beta1", Kind: "EndpointSlice", Introduced: "1.17", Deprecated: "1.21", Removed: "1.25", Replacement: "discovery.k8s.io/v1"},
+
+		{Group: "events.k8s.io", Version: "v1", Kind: "Event", Introduced: "1.19"},
+		{Group: "events.k8s.io", Version: "v1beta1", Kind: "Event", Introduced: "1.8", Deprecated: "1.19", Removed: "1.25", Replacement: "events.k8s.io/v1"},
+
+		{Group: "extensions", Version: "v1beta1", Kind: "DaemonSet", Introduced: "1.2", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+		{Group: "extensions", Version: "v1beta1", Kind: "Deployment", Introduced: "1.2", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+		{Group: "extensions", Version: "v1beta1", Kind: "Ingress", Introduced: "1.1", Deprecated: "1.14", Removed: "1.22", Replacement: "networking.k8s.io/v1"},
+		{Group: "extensions", Version: "v1beta1", Kind: "NetworkPolicy", Introduced: "1.3", Deprecated: "1.9", Removed: "1.16", Replacement: "networking.k8s.io/v1"},
+		{Group: "extensions", Version: "v1beta1", Kind: "PodSecurityPolicy", Introduced: "1.3", Deprecated: "1.10", Removed: "1.16", Replacement: "policy/v1beta1"},
+		{Group: "extensions", Version: "v1beta1", Kind: "ReplicaSet", Introduced: "1.2", Deprecated: "1.9", Removed: "1.16", Replacement: "apps/v1"},
+
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1", Kind: "FlowSchema", Introduced: "1.29"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1", Kind: "PriorityLevelConfiguration", Introduced: "1.29"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta1", Kind: "FlowSchema", Introduced: "1.20", Deprecated: "1.23", Removed: "1.26", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta1", Kind: "PriorityLevelConfiguration", Introduced: "1.20", Deprecated: "1.23", Removed: "1.26", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Kind: "FlowSchema", Introduced: "1.23", Deprecated: "1.26", Removed: "1.29", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Kind: "PriorityLevelConfiguration", Introduced: "1.23", Deprecated: "1.26", Removed: "1.29", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "FlowSchema", Introduced: "1.26", Deprecated: "1.29", Removed: "1.32", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "PriorityLevelConfiguration", Introduced: "1.26", Deprecated: "1.29", Removed: "1.32", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+
+		{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress", Introduced: "1.19"},
+		{Group: "networking.k8s.io", Version: "v1", Kind: "IngressClass", Introduced: "1.19"},
+		{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy", Introduced: "1.7"},
+		{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress", Introduced: "1.14", Deprecated: "1.19", Removed: "1.22", Replacement: "networking.k8s.io/v1"},
+		{Group: "networking.k8s.io", Version: "v1beta1", Kind: "IngressClass", Introduced: "1.18", Deprecated: "1.19", Removed: "1.22", Replacement: "networking.k8s.io/v1"},
+
+		{Group: "node.k8s.io", Version: "v1", Kind: "RuntimeClass", Introduced: "1.20"},
+		{Group: "node.k8s.io", Version: "v1beta1", Kind: "RuntimeClass", Introduced: "1.14", Deprecated: "1.22", Removed: "1.25", Replacement: "node.k8s.io/v1"},
+
+		{Group: "policy", Version: "v1", Kind: "PodDisruptionBudget", Introduced: "1.21"},
+		{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget", Introduced: "1.5", Deprecated: "1.21", Removed: "1.25", Replacement: "policy/v1"},
+		{Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy", Introduced: "1.10", Deprecated: "1.21", Removed: "1.25"},
+
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Introduced: "1.8"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding", Introduced: "1.8"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role", Introduced: "1.8"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding", Introduced: "1.8"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "ClusterRole", Introduced: "1.6", Deprecated: "1.17", Removed: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "ClusterRoleBinding", Introduced: "1.6", Deprecated: "1.17", Removed: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "Role", Introduced: "1.6", Deprecated: "1.17", Removed: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "RoleBinding", Introduced: "1.6", Deprecated: "1.17", Removed: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+
+		{Group: "scheduling.k8s.io", Version: "v1", Kind: "PriorityClass", Introduced: "1.14"},
+		{Group: "scheduling.k8s.io", Version: "v1beta1", Kind: "PriorityClass", Introduced: "1.11", Deprecated: "1.14", Removed: "1.22", Replacement: "scheduling.k8s.io/v1"},
+
+		{Group: "storage.k8s.io", Version: "v1", Kind: "CSIDriver", Introduced: "1.18"},
+		{Group: "storage.k8s.io", Version: "v1", Kind: "CSINode", Introduced: "1.17"},
+		{Group: "storage.k8s.io", Version: "v1", Kind: "CSIStorageCapacity", Introduced: "1.24"},
+		{Group: "storage.k8s.io", Version: "v1", Kind: "StorageClass", Introduced: "1.6"},
+		{Group: "storage.k8s.io", Version: "v1", Kind: "VolumeAttachment", Introduced: "1.13"},
+		{Group: "storage.k8s.io", Version: "v1beta1", Kind: "CSIDriver", Introduced: "1.14", Deprecated: "1.19", Removed: "1.22", Replacement: "storage.k8s.io/v1"},
+		{Group: "storage.k8s.io", Version: "v1beta1", Kind: "CSINode", Introduced: "1.14", Deprecated: "1.17", Removed: "1.22", Replacement: "storage.k8s.io/v1"},
+		{Group: "storage.k8s.io", Version: "v1beta1", Kind: "CSIStorageCapacity", Introduced: "1.21", Deprecated: "1.24", Removed: "1.27", Replacement: "storage.k8s.io/v1"},
+		{Group: "storage.k8s.io", Version: "v1beta1", Kind: "StorageClass", Introduced: "1.4", Deprecated: "1.19", Removed: "1.22", Replacement: "storage.k8s.io/v1"},
+		{Group: "storage.k8s.io", Version: "v1beta1", Kind: "VolumeAttachment", Introduced: "1.10", Deprecated: "1.19", Removed: "1.22", Replacement: "storage.k8s.io/v1"},
+	},
+}
+
+// Lookup returns the API objects of the given apiVersion and kind belong to; ok is false when the catalog does not
+// know about them.
+func (c KubeAPICatalog) Lookup(apiVersion, kind string) (api KubeAPI, ok bool) {
+	for _, a := range c.APIs {
+		if a.APIVersion() == apiVersion && a.Kind == kind {
+			return a, true
+		}
+	}
+	return KubeAPI{}, false
+}
+
+// KnowsGroup tells whether the given API group is one of the catalog's; objects of unknown groups, usually custom
+// resources, can't be checked against the catalog.
+func (c KubeAPICatalog) KnowsGroup(group string) bool {
+	for _, a := range c.APIs {
+		if a.Group == group {
+			return true
+		}
+	}
+	return false
+}
+
+// KnowsGroupVersion tells whether any API of the given apiVersion is one of the catalog's.
+func (c KubeAPICatalog) KnowsGroupVersion(apiVersion string) bool {
+	for _, a := range c.APIs {
+		if a.APIVersion() == apiVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// kubeMinorVersions returns the Kubernetes minor versions up to the catalog's version satisfying the given
+// constraint.
+func (c KubeAPICatalog) kubeMinorVersions(constraint *semver.Constraints) []*semver.Version {
+	latest := semver.MustParse(c.KubeVersion)
+
+	versions := make([]*semver.Version, 0)
+	for minor := uint64(0); minor <= latest.Minor(); minor++ {
+		v := semver.MustParse(fmt.Sprintf("%d.%d.0", latest.Major(), minor))
+		if satisfiesMinorVersion(constraint, v) {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// constraintVersionRegexp matches the versions a constraint compares against, such as 1.20.3 in >=1.20.3 <1.20.9.
+var constraintVersionRegexp = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// satisfiesMinorVersion tells whether the minor version of v satisfies the given constraint, which is the case when
+// any of its patch releases does. The patch releases satisfying a constraint are ranges bounded by the first and last
+// patch releases or by the versions the constraint compares against, so only these bounds and their neighbours are
+// tested.
+func satisfiesMinorVersion(constraint *semver.Constraints, v *semver.Version) bool {
+	patches := []uint64{0, 1 << 31}
+	for _, m := range constraintVersionRegexp.FindAllStringSubmatch(constraint.String(), -1) {
+		if m[1] != fmt.Sprint(v.Major()) || m[2] != fmt.Sprint(v.Minor()) || m[3] == "" {
+			continue
+		}
+		patch, err := strconv.ParseUint(m[3], 10, 64)
+		if err != nil || patch >= 1<<31 {
+			continue
+		}
+		patches = append(patches, patch, patch+1)
+		if patch > 0 {
+			patches = append(patches, patch-1)
+		}
+	}
+
+	for _, patch := range patches {
+		if constraint.Check(semver.MustParse(fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), patch))) {
+			return true
+		}
+	}
+	return false
+}
+
+// kubeAPIProblems returns the problems of using the given object's API across the given Kubernetes minor versions:
+// unavailable ones make the chart fail on some of these versions, deprecated ones only warn about a coming removal.
+func (c KubeAPICatalog) kubeAPIProblems(obj *unstructured.Unstructured, versions []*semver.Version) (
+	unavailable, deprecated string) {
+
+	if len(versions) == 0 {
+		return "", ""
+	}
+	first, last := versions[0], versions[len(versions)-1]
+
+	gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+	if err != nil || !c.KnowsGroup(gv.Group) {
+		return "", ""
+	}
+
+	api, ok := c.Lookup(obj.GetAPIVersion(), obj.GetKind())
+	switch {
+	case !ok && c.KnowsGroupVersion(obj.GetAPIVersion()):
+		// the catalog only holds the kinds charts usually create objects of, others can't be checked
+		return "", ""
+	case !ok:
+		return fmt.Sprintf("uses %s %s, which is not a known Kubernetes API", obj.GetAPIVersion(), obj.GetKind()), ""
+	}
+
+	name := api.APIVersion() + " " + api.Kind
+	replacement := ""
+	if api.Replacement != "" {
+		replacement = fmt.Sprintf("; use %s instead", api.Replacement)
+	}
+
+	switch {
+	case semver.MustParse(api.Introduced).GreaterThan(first):
+		unavailable = fmt.Sprintf("uses %s, which is not available before Kubernetes %s", name, api.Introduced)
+	case api.Removed != "" && !semver.MustParse(api.Removed).GreaterThan(last):
+		unavailable = fmt.Sprintf("uses %s, which is removed in Kubernetes %s%s", name, api.Removed, replacement)
+	case api.Deprecated != "" && !semver.MustParse(api.Deprecated).GreaterThan(last):
+		deprecated = fmt.Sprintf("uses %s, which is deprecated in Kubernetes %s%s", name, api.Deprecated, replacement)
+	}
+
+	return unavailable, deprecated
+}
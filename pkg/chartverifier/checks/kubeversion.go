@@ -0,0 +1,149 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrKubeVersionWithoutLowerBound is returned for kubeVersion constraints satisfied by any old Kubernetes version.
+	ErrKubeVersionWithoutLowerBound = errors.New("constraint has no lower bound")
+	// ErrKubeVersionNotSatisfiable is returned for kubeVersion constraints no Kubernetes version satisfies.
+	ErrKubeVersionNotSatisfiable = errors.New("constraint is not satisfied by any Kubernetes version")
+)
+
+// constraintBoundRegexp matches the versions a constraint compares against, including partial ones such as 2 in >=2.
+var constraintBoundRegexp = regexp.MustCompile(`\d+(?:\.\d+){0,2}`)
+
+// OpenshiftRelease is an OpenShift Container Platform release and the Kubernetes minor version it is based on.
+type OpenshiftRelease struct {
+	Version     string
+	KubeVersion string
+}
+
+// DefaultOpenshiftReleases are the OpenShift Container Platform 4 releases, oldest first.
+var DefaultOpenshiftReleases = []OpenshiftRelease{
+	{Version: "4.1", KubeVersion: "1.13"},
+	{Version: "4.2", KubeVersion: "1.14"},
+	{Version: "4.3", KubeVersion: "1.16"},
+	{Version: "4.4", KubeVersion: "1.17"},
+	{Version: "4.5", KubeVersion: "1.18"},
+	{Version: "4.6", KubeVersion: "1.19"},
+	{Version: "4.7", KubeVersion: "1.20"},
+	{Version: "4.8", KubeVersion: "1.21"},
+	{Version: "4.9", KubeVersion: "1.22"},
+	{Version: "4.10", KubeVersion: "1.23"},
+	{Version: "4.11", KubeVersion: "1.24"},
+	{Version: "4.12", KubeVersion: "1.25"},
+	{Version: "4.13", KubeVersion: "1.26"},
+	{Version: "4.14", KubeVersion: "1.27"},
+	{Version: "4.15", KubeVersion: "1.28"},
+	{Version: "4.16", KubeVersion: "1.29"},
+}
+
+// ParseKubeVersion parses the given Chart.yaml kubeVersion as a semver constraint, rejecting constraints any old
+// Kubernetes version satisfies, which accept 1.0.0, and those no Kubernetes version satisfies. Constraints only
+// satisfied by Kubernetes versions newer than the ones known to DefaultKubeAPICatalog are valid; see
+// IsNewerThanKnownKubeVersions.
+func ParseKubeVersion(kubeVersion string) (*semver.Constraints, error) {
+	constraint, err := semver.NewConstraint(kubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if constraint.Check(semver.MustParse("0.0.0")) || constraint.Check(semver.MustParse("1.0.0")) {
+		return nil, ErrKubeVersionWithoutLowerBound
+	}
+
+	if len(DefaultKubeAPICatalog.kubeMinorVersions(constraint)) == 0 && !IsNewerThanKnownKubeVersions(constraint) {
+		return nil, ErrKubeVersionNotSatisfiable
+	}
+
+	return constraint, nil
+}
+
+// IsNewerThanKnownKubeVersions tells whether the given constraint is satisfied by a Kubernetes version newer than the
+// ones known to DefaultKubeAPICatalog, and by none of these.
+func IsNewerThanKnownKubeVersions(constraint *semver.Constraints) bool {
+	if len(DefaultKubeAPICatalog.kubeMinorVersions(constraint)) > 0 {
+		return false
+	}
+
+	latest := semver.MustParse(DefaultKubeAPICatalog.KubeVersion)
+	for _, v := range boundCandidates(constraint) {
+		if (v.Major() > latest.Major() || v.Major() == latest.Major() && v.Minor() > latest.Minor()) &&
+			constraint.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// boundCandidates returns the versions worth checking to find whether a version satisfies the given constraint. The
+// versions satisfying a constraint are ranges bounded by the versions it compares against, so these versions, their
+// neighbours and a version newer than any of them are enough.
+func boundCandidates(constraint *semver.Constraints) []*semver.Version {
+	const maxPart = 1 << 31
+
+	candidates := []*semver.Version{semver.MustParse(fmt.Sprintf("%d.0.0", uint64(maxPart)))}
+	add := func(major, minor, patch uint64) {
+		candidates = append(candidates, semver.MustParse(fmt.Sprintf("%d.%d.%d", major, minor, patch)))
+	}
+
+	for _, bound := range constraintBoundRegexp.FindAllString(constraint.String(), -1) {
+		v, err := semver.NewVersion(bound)
+		if err != nil || v.Major() >= maxPart || v.Minor() >= maxPart || v.Patch() >= maxPart {
+			continue
+		}
+		add(v.Major(), v.Minor(), v.Patch())
+		add(v.Major(), v.Minor(), v.Patch()+1)
+		add(v.Major(), v.Minor()+1, 0)
+		add(v.Major()+1, 0, 0)
+		if v.Patch() > 0 {
+			add(v.Major(), v.Minor(), v.Patch()-1)
+		}
+		if v.Minor() > 0 {
+			add(v.Major(), v.Minor()-1, maxPart)
+		}
+		if v.Major() > 0 {
+			add(v.Major()-1, maxPart, maxPart)
+		}
+	}
+	return candidates
+}
+
+// SupportedOpenshiftVersions returns the OpenShift releases whose Kubernetes version satisfies the given Chart.yaml
+// kubeVersion, oldest first.
+func SupportedOpenshiftVersions(kubeVersion string) ([]string, error) {
+	constraint, err := ParseKubeVersion(kubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0)
+	for _, r := range DefaultOpenshiftReleases {
+		if satisfiesMinorVersion(constraint, semver.MustParse(r.KubeVersion)) {
+			versions = append(versions, r.Version)
+		}
+	}
+	return versions, nil
+}
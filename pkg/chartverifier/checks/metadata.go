@@ -0,0 +1,187 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+const (
+	// RequiredAnnotationsConfigKey is the key of the has-complete-metadata configuration holding the required
+	// annotations.
+	RequiredAnnotationsConfigKey = "annotations"
+	appVersionField              = "appVersion"
+)
+
+// DefaultRequiredAnnotations are the annotations charts must set when no required annotations have been configured.
+var DefaultRequiredAnnotations = []string{
+	NameAnnotation,
+	ProviderAnnotation,
+}
+
+// dataURIRegexp matches image data URIs, capturing whether the data is base64 encoded and the data itself.
+var dataURIRegexp = regexp.MustCompile(`^data:image/[\w.+-]+(?:;[\w.+-]+=[\w.+-]+)*(;base64)?,(.+)$`)
+
+// chartMetadataProblems returns every missing or malformed Chart.yaml field of the given chart, including the given
+// required annotations.
+func chartMetadataProblems(c *chart.Chart, requiredAnnotations []string) ([]string, error) {
+	m := c.Metadata
+	problems := make([]string, 0)
+
+	if strings.TrimSpace(m.Description) == "" {
+		problems = append(problems, "description is missing")
+	}
+
+	if m.Home == "" {
+		problems = append(problems, "home is missing")
+	} else if !isWebURL(m.Home) {
+		problems = append(problems, fmt.Sprintf("home %q is not an http or https URL", m.Home))
+	}
+
+	if len(m.Sources) == 0 {
+		problems = append(problems, "sources are missing")
+	}
+	for _, s := range m.Sources {
+		if !isWebURL(s) {
+			problems = append(problems, fmt.Sprintf("source %q is not an http or https URL", s))
+		}
+	}
+
+	if len(m.Maintainers) == 0 {
+		problems = append(problems, "maintainers are missing")
+	}
+	for i, mt := range m.Maintainers {
+		if mt == nil || strings.TrimSpace(mt.Name) == "" {
+			problems = append(problems, fmt.Sprintf("maintainer %d has no name", i+1))
+			continue
+		}
+		if mt.Email == "" && mt.URL == "" {
+			problems = append(problems, fmt.Sprintf("maintainer %q has neither email nor url", mt.Name))
+		}
+		if mt.Email != "" {
+			if _, err := mail.ParseAddress(mt.Email); err != nil {
+				problems = append(problems, fmt.Sprintf("maintainer %q email %q is not valid", mt.Name, mt.Email))
+			}
+		}
+		if mt.URL != "" && !isWebURL(mt.URL) {
+			problems = append(problems, fmt.Sprintf("maintainer %q url %q is not an http or https URL", mt.Name, mt.URL))
+		}
+	}
+
+	if m.Icon == "" {
+		problems = append(problems, "icon is missing")
+	} else if !isWebURL(m.Icon) && !isImageDataURI(m.Icon) {
+		problems = append(problems, fmt.Sprintf("icon %q is neither an http or https URL nor an image data URI",
+			abbreviate(m.Icon)))
+	}
+
+	if _, err := semver.StrictNewVersion(m.Version); err != nil {
+		problems = append(problems, fmt.Sprintf("version %q is not a strict semver version", m.Version))
+	}
+
+	if m.AppVersion == "" {
+		problems = append(problems, "appVersion is missing")
+	} else {
+		quoted, err := isAppVersionQuoted(c)
+		if err != nil {
+			return nil, err
+		}
+		if !quoted {
+			problems = append(problems, fmt.Sprintf("appVersion %s is not quoted", m.AppVersion))
+		}
+	}
+
+	for _, a := range requiredAnnotations {
+		if strings.TrimSpace(m.Annotations[a]) == "" {
+			problems = append(problems, fmt.Sprintf("annotation %q is missing", a))
+		}
+	}
+	if s, ok := m.Annotations[SupportURLAnnotation]; ok && !isWebURL(s) {
+		problems = append(problems, fmt.Sprintf("annotation %q value %q is not an http or https URL",
+			SupportURLAnnotation, s))
+	}
+
+	return problems, nil
+}
+
+// isWebURL tells whether s is an absolute http or https URL.
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// isImageDataURI tells whether s is a data URI holding an image.
+func isImageDataURI(s string) bool {
+	m := dataURIRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	if m[1] != "" {
+		_, err := base64.StdEncoding.DecodeString(m[2])
+		return err == nil
+	}
+	return true
+}
+
+// abbreviate shortens s, usually a data URI, so it can be reported.
+func abbreviate(s string) string {
+	const max = 64
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
+
+// isAppVersionQuoted tells whether the appVersion of the given chart is quoted in its Chart.yaml, so YAML doesn't
+// read versions such as 1.10 as numbers.
+func isAppVersionQuoted(c *chart.Chart) (bool, error) {
+	for _, f := range c.Raw {
+		if f.Name != chartutil.ChartfileName {
+			continue
+		}
+
+		doc := yaml.Node{}
+		if err := yaml.Unmarshal(f.Data, &doc); err != nil {
+			return false, errors.Wrapf(err, "parsing %s", f.Name)
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			return false, nil
+		}
+
+		fields := doc.Content[0].Content
+		for i := 0; i+1 < len(fields); i += 2 {
+			if fields[i].Value == appVersionField {
+				return fields[i+1].Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0, nil
+			}
+		}
+		return false, nil
+	}
+
+	// charts not loaded from files, whose Chart.yaml can't be inspected
+	return true, nil
+}
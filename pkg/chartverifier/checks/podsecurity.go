@@ -0,0 +1,196 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodSecurityLevel is a level of the Kubernetes Pod Security Standards.
+type PodSecurityLevel string
+
+const (
+	PodSecurityBaseline   PodSecurityLevel = "baseline"
+	PodSecurityRestricted PodSecurityLevel = "restricted"
+	// DefaultPodSecurityLevel is the level pods are evaluated against when no level has been configured.
+	DefaultPodSecurityLevel = PodSecurityRestricted
+	// PodSecurityLevelConfigKey is the key of the pods-meet-pod-security-standards configuration holding the level.
+	PodSecurityLevelConfigKey = "level"
+)
+
+// baselineCapabilities are the capabilities containers may add at the baseline level.
+var baselineCapabilities = map[corev1.Capability]bool{
+	"AUDIT_WRITE":      true,
+	"CHOWN":            true,
+	"DAC_OVERRIDE":     true,
+	"FOWNER":           true,
+	"FSETID":           true,
+	"KILL":             true,
+	"MKNOD":            true,
+	"NET_BIND_SERVICE": true,
+	"SETFCAP":          true,
+	"SETGID":           true,
+	"SETPCAP":          true,
+	"SETUID":           true,
+	"SYS_CHROOT":       true,
+}
+
+// restrictedCapabilities are the capabilities containers may add at the restricted level.
+var restrictedCapabilities = map[corev1.Capability]bool{
+	"NET_BIND_SERVICE": true,
+}
+
+// parsePodSecurityLevel returns the Pod Security Standards level with the given name.
+func parsePodSecurityLevel(name string) (PodSecurityLevel, error) {
+	switch l := PodSecurityLevel(strings.ToLower(name)); l {
+	case PodSecurityBaseline, PodSecurityRestricted:
+		return l, nil
+	default:
+		return "", errors.Errorf("unknown Pod Security Standards level %q", name)
+	}
+}
+
+// podSecurityViolations returns the Pod Security Standards controls of the given level violated by the given pod
+// spec: first those set at the pod level, then those of each init and regular container, each entry prefixed by what
+// violates them.
+func podSecurityViolations(spec *corev1.PodSpec, level PodSecurityLevel) []string {
+	violations := make([]string, 0)
+
+	pod := make([]string, 0)
+	if spec.HostNetwork {
+		pod = append(pod, "hostNetwork is true")
+	}
+	if spec.HostPID {
+		pod = append(pod, "hostPID is true")
+	}
+	if spec.HostIPC {
+		pod = append(pod, "hostIPC is true")
+	}
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			pod = append(pod, fmt.Sprintf("hostPath volume %q", v.Name))
+		}
+	}
+	if len(pod) > 0 {
+		violations = append(violations, "pod: "+strings.Join(pod, "; "))
+	}
+
+	podContext := spec.SecurityContext
+	if podContext == nil {
+		podContext = &corev1.PodSecurityContext{}
+	}
+
+	add := func(kind string, c corev1.Container) {
+		if v := containerSecurityViolations(podContext, c, level); len(v) > 0 {
+			violations = append(violations, fmt.Sprintf("%s %q: %s", kind, c.Name, strings.Join(v, "; ")))
+		}
+	}
+	for _, c := range spec.InitContainers {
+		add("init container", c)
+	}
+	for _, c := range spec.Containers {
+		add("container", c)
+	}
+
+	return violations
+}
+
+// containerSecurityViolations returns the Pod Security Standards controls of the given level violated by the given
+// container, taking into account the settings it inherits from its pod.
+func containerSecurityViolations(pod *corev1.PodSecurityContext, c corev1.Container, level PodSecurityLevel) []string {
+	sc := c.SecurityContext
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	}
+
+	violations := make([]string, 0)
+
+	if sc.Privileged != nil && *sc.Privileged {
+		violations = append(violations, "privileged is true")
+	}
+
+	if level == PodSecurityRestricted && (sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation) {
+		violations = append(violations, "allowPrivilegeEscalation is not false")
+	}
+
+	allowed := baselineCapabilities
+	if level == PodSecurityRestricted {
+		allowed = restrictedCapabilities
+	}
+	if sc.Capabilities != nil {
+		added := make([]string, 0)
+		for _, c := range sc.Capabilities.Add {
+			if !allowed[c] {
+				added = append(added, string(c))
+			}
+		}
+		if len(added) > 0 {
+			violations = append(violations, "capabilities add "+strings.Join(added, ", "))
+		}
+	}
+	if level == PodSecurityRestricted && !dropsAllCapabilities(sc.Capabilities) {
+		violations = append(violations, "capabilities do not drop ALL")
+	}
+
+	if level == PodSecurityRestricted {
+		runAsNonRoot := pod.RunAsNonRoot
+		if sc.RunAsNonRoot != nil {
+			runAsNonRoot = sc.RunAsNonRoot
+		}
+		if runAsNonRoot == nil || !*runAsNonRoot {
+			violations = append(violations, "runAsNonRoot is not true")
+		}
+
+		runAsUser := pod.RunAsUser
+		if sc.RunAsUser != nil {
+			runAsUser = sc.RunAsUser
+		}
+		if runAsUser != nil && *runAsUser == 0 {
+			violations = append(violations, "runAsUser is 0")
+		}
+	}
+
+	seccomp := pod.SeccompProfile
+	if sc.SeccompProfile != nil {
+		seccomp = sc.SeccompProfile
+	}
+	switch {
+	case level == PodSecurityRestricted && (seccomp == nil ||
+		(seccomp.Type != corev1.SeccompProfileTypeRuntimeDefault && seccomp.Type != corev1.SeccompProfileTypeLocalhost)):
+		violations = append(violations, "seccompProfile is not RuntimeDefault or Localhost")
+	case seccomp != nil && seccomp.Type == corev1.SeccompProfileTypeUnconfined:
+		violations = append(violations, "seccompProfile is Unconfined")
+	}
+
+	return violations
+}
+
+func dropsAllCapabilities(capabilities *corev1.Capabilities) bool {
+	if capabilities == nil {
+		return false
+	}
+	for _, c := range capabilities.Drop {
+		if strings.ToUpper(string(c)) == "ALL" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// objectReference identifies an object referred to by a workload; all of them are core objects.
+type objectReference struct {
+	Kind string
+	Name string
+}
+
+// GroupVersionKind returns the group, version and kind of the referred object.
+func (r objectReference) GroupVersionKind() schema.GroupVersionKind {
+	return corev1.SchemeGroupVersion.WithKind(r.Kind)
+}
+
+func (r objectReference) String() string {
+	return r.Kind + "/" + r.Name
+}
+
+// podSpecReferences returns the Secrets, ConfigMaps, PersistentVolumeClaims and ServiceAccount the given pod spec
+// requires to exist before its pods can start; optional references are ignored.
+func podSpecReferences(spec *corev1.PodSpec) []objectReference {
+	refs := make([]objectReference, 0)
+	add := func(kind, name string, optional *bool) {
+		if name != "" && (optional == nil || !*optional) {
+			refs = append(refs, objectReference{Kind: kind, Name: name})
+		}
+	}
+
+	if spec.ServiceAccountName != "" && spec.ServiceAccountName != "default" {
+		add("ServiceAccount", spec.ServiceAccountName, nil)
+	}
+
+	for _, s := range spec.ImagePullSecrets {
+		add("Secret", s.Name, nil)
+	}
+
+	for _, v := range spec.Volumes {
+		switch {
+		case v.Secret != nil:
+			add("Secret", v.Secret.SecretName, v.Secret.Optional)
+		case v.ConfigMap != nil:
+			add("ConfigMap", v.ConfigMap.Name, v.ConfigMap.Optional)
+		case v.PersistentVolumeClaim != nil:
+			add("PersistentVolumeClaim", v.PersistentVolumeClaim.ClaimName, nil)
+		case v.Projected != nil:
+			for _, p := range v.Projected.Sources {
+				if p.Secret != nil {
+					add("Secret", p.Secret.Name, p.Secret.Optional)
+				}
+				if p.ConfigMap != nil {
+					add("ConfigMap", p.ConfigMap.Name, p.ConfigMap.Optional)
+				}
+			}
+		}
+	}
+
+	for _, c := range append(spec.InitContainers, spec.Containers...) {
+		for _, e := range c.EnvFrom {
+			if e.SecretRef != nil {
+				add("Secret", e.SecretRef.Name, e.SecretRef.Optional)
+			}
+			if e.ConfigMapRef != nil {
+				add("ConfigMap", e.ConfigMapRef.Name, e.ConfigMapRef.Optional)
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if r := e.ValueFrom.SecretKeyRef; r != nil {
+				add("Secret", r.Name, r.Optional)
+			}
+			if r := e.ValueFrom.ConfigMapKeyRef; r != nil {
+				add("ConfigMap", r.Name, r.Optional)
+			}
+		}
+	}
+
+	return refs
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// longRunningKinds are the kinds of the workloads whose containers are expected to run indefinitely.
+var longRunningKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+}
+
+// podSpecProbeProblems returns the probe problems of the regular containers of the given pod spec, one entry per
+// container having any: missing readiness or liveness probes, and probes on ports the container doesn't declare.
+func podSpecProbeProblems(spec *corev1.PodSpec) []string {
+	problems := make([]string, 0)
+	for _, c := range spec.Containers {
+		p := make([]string, 0)
+		if c.ReadinessProbe == nil {
+			p = append(p, "no readiness probe")
+		}
+		if c.LivenessProbe == nil {
+			p = append(p, "no liveness probe")
+		}
+		for _, probe := range []struct {
+			name  string
+			probe *corev1.Probe
+		}{
+			{"readiness", c.ReadinessProbe}, {"liveness", c.LivenessProbe}, {"startup", c.StartupProbe},
+		} {
+			if port, ok := probePort(probe.probe); ok && !declaresPort(c, port) {
+				p = append(p, fmt.Sprintf("%s probe port %s matches no container port", probe.name, portString(port)))
+			}
+		}
+		if len(p) > 0 {
+			problems = append(problems, fmt.Sprintf("container %q: %s", c.Name, strings.Join(p, "; ")))
+		}
+	}
+	return problems
+}
+
+// probePort returns the port the given probe connects to; ok is false for command probes.
+func probePort(p *corev1.Probe) (port intstr.IntOrString, ok bool) {
+	switch {
+	case p == nil:
+		return port, false
+	case p.HTTPGet != nil:
+		return p.HTTPGet.Port, true
+	case p.TCPSocket != nil:
+		return p.TCPSocket.Port, true
+	default:
+		return port, false
+	}
+}
+
+// declaresPort tells whether the given container declares the given port, either by number or by name.
+func declaresPort(c corev1.Container, port intstr.IntOrString) bool {
+	for _, p := range c.Ports {
+		if port.Type == intstr.Int && p.ContainerPort == port.IntVal ||
+			port.Type == intstr.String && p.Name != "" && p.Name == port.StrVal {
+			return true
+		}
+	}
+	return false
+}
+
+// portString returns the given port number, or its quoted name.
+func portString(port intstr.IntOrString) string {
+	if port.Type == intstr.String {
+		return fmt.Sprintf("%q", port.StrVal)
+	}
+	return port.String()
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+const (
+	// ProvenanceKeyringConfigKey is the key of the has-verified-provenance configuration holding the path of the
+	// OpenPGP keyring of the known publishers.
+	ProvenanceKeyringConfigKey = "keyring"
+
+	provenanceExtension = ".prov"
+	// provenanceDigestMismatchError prefixes the error helm reports when the archive digest differs from the signed one.
+	provenanceDigestMismatchError = "sha256 sum does not match"
+)
+
+// defaultKeyring returns the keyring helm verifies charts against by default: the public keyring of the GnuPG home.
+func defaultKeyring() string {
+	if home, ok := os.LookupEnv("GNUPGHOME"); ok {
+		return filepath.Join(home, "pubring.gpg")
+	}
+	home, _ := homedir.Dir()
+	return filepath.Join(home, ".gnupg", "pubring.gpg")
+}
+
+// chartProvenance locates the archive of the chart at the given uri and its provenance file, downloading remote ones
+// into a temporary directory the returned cleanup function removes. ok is false when the chart is not an archive or
+// has no provenance file.
+func chartProvenance(uri string) (archive, prov string, cleanup func(), ok bool, err error) {
+	cleanup = func() {}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", cleanup, false, err
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		dir, err := ioutil.TempDir("", "chart-verifier-provenance")
+		if err != nil {
+			return "", "", cleanup, false, err
+		}
+		cleanup = func() { _ = os.RemoveAll(dir) }
+
+		// the provenance file names the archive it was signed for, so the archive keeps its name
+		archive = filepath.Join(dir, path.Base(u.Path))
+		prov = archive + provenanceExtension
+		if _, err := downloadFile(u.String(), archive); err != nil {
+			return "", "", cleanup, false, err
+		}
+		found, err := downloadFile(u.String()+provenanceExtension, prov)
+		return archive, prov, cleanup, found, err
+	case "file", "":
+		archive = u.Path
+		if fi, err := os.Stat(archive); err != nil {
+			return "", "", cleanup, false, err
+		} else if fi.IsDir() {
+			return archive, "", cleanup, false, nil
+		}
+		prov = archive + provenanceExtension
+		if _, err := os.Stat(prov); os.IsNotExist(err) {
+			return archive, prov, cleanup, false, nil
+		} else if err != nil {
+			return "", "", cleanup, false, err
+		}
+		return archive, prov, cleanup, true, nil
+	default:
+		return "", "", cleanup, false, errors.Errorf("scheme %q not supported", u.Scheme)
+	}
+}
+
+// downloadFile saves the content at the given url into dst; found is false when the url doesn't exist.
+func downloadFile(url, dst string) (found bool, err error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode != http.StatusOK:
+		return false, errors.Errorf("downloading %s: %s", url, resp.Status)
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// isDigestMismatch tells whether the given error of the verification of a provenance file is the mismatch of the
+// archive digest and the signed one, rather than any other verification failure.
+func isDigestMismatch(err error) bool {
+	return strings.HasPrefix(err.Error(), provenanceDigestMismatchError)
+}
+
+// signerIdentity returns the identity of the given signer: its primary user id, or the first one in lexical order
+// when none is marked primary, along with its key id.
+func signerIdentity(e *openpgp.Entity) string {
+	names := make([]string, 0, len(e.Identities))
+	for name, id := range e.Identities {
+		if id.SelfSignature != nil && id.SelfSignature.IsPrimaryId != nil && *id.SelfSignature.IsPrimaryId {
+			names = []string{name}
+			break
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	identity := "key " + e.PrimaryKey.KeyIdString()
+	if len(names) > 0 {
+		identity = names[0] + " (" + identity + ")"
+	}
+	return identity
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// valuesHeadingRegexp matches the headings of the README sections usually documenting the chart values.
+	valuesHeadingRegexp = regexp.MustCompile(`(?i)configuration|parameters|values`)
+	// valuesColumnRegexp matches the header of the table column usually holding the value keys.
+	valuesColumnRegexp = regexp.MustCompile(`(?i)^(parameter|key|name|value)s?$`)
+	// tableSeparatorRegexp matches the line separating the header of a markdown table from its rows.
+	tableSeparatorRegexp = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+)
+
+// markdownTable is a table found in a markdown document.
+type markdownTable struct {
+	// Heading is the heading of the section containing the table.
+	Heading string
+	Header  []string
+	Rows    [][]string
+}
+
+// markdownTables returns the tables contained in the given markdown document.
+func markdownTables(doc string) []markdownTable {
+	lines := strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n")
+
+	tables := make([]markdownTable, 0)
+	heading := ""
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "#") {
+			heading = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			continue
+		}
+		if !strings.Contains(line, "|") || i+1 >= len(lines) ||
+			!tableSeparatorRegexp.MatchString(strings.TrimSpace(lines[i+1])) {
+			continue
+		}
+
+		table := markdownTable{Heading: heading, Header: markdownTableCells(line)}
+		for i += 2; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+			table.Rows = append(table.Rows, markdownTableCells(strings.TrimSpace(lines[i])))
+		}
+		tables = append(tables, table)
+	}
+
+	return tables
+}
+
+func markdownTableCells(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+	for i, c := range cells {
+		cells[i] = strings.TrimSpace(c)
+	}
+	return cells
+}
+
+// readmeValuesKeys returns the value keys documented by the given README: the first column of the first table whose
+// section heading indicates it documents the chart values or, when there is none, of the first table whose first
+// column header does, since columns such as "Name" are found in other tables too. Returns false when no such table
+// exists.
+func readmeValuesKeys(readme string) ([]string, bool) {
+	tables := markdownTables(readme)
+	for _, documentsValues := range []func(markdownTable) bool{
+		func(t markdownTable) bool { return valuesHeadingRegexp.MatchString(t.Heading) },
+		func(t markdownTable) bool { return valuesColumnRegexp.MatchString(t.Header[0]) },
+	} {
+		for _, t := range tables {
+			if len(t.Header) > 0 && documentsValues(t) {
+				return tableValuesKeys(t), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// tableValuesKeys returns the value keys documented in the first column of the given table.
+func tableValuesKeys(t markdownTable) []string {
+	keys := make([]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		fields := strings.Fields(strings.ReplaceAll(row[0], "`", ""))
+		if len(fields) == 0 {
+			continue
+		}
+		key := fields[0]
+		// list elements are documented as part of the list itself
+		if i := strings.Index(key, "["); i >= 0 {
+			key = key[:i]
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// flattenValues returns the dot separated paths of the leaves of the given values; lists and empty maps are
+// considered leaves.
+func flattenValues(values map[string]interface{}) []string {
+	keys := make([]string, 0)
+	for _, k := range sortedKeys(values) {
+		if m, ok := values[k].(map[string]interface{}); ok && len(m) > 0 {
+			for _, sub := range flattenValues(m) {
+				keys = append(keys, k+"."+sub)
+			}
+			continue
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// coversKey tells whether key is either the documented key or one of its descendants.
+func coversKey(documented, key string) bool {
+	return key == documented || strings.HasPrefix(key, documented+".")
+}
+
+// compareReadmeValues compares the keys documented in the README with the given flattened value keys, returning the
+// values not documented and the documented keys not present in the values.
+func compareReadmeValues(documented, values []string) (undocumented, stale []string) {
+	undocumented = make([]string, 0)
+	for _, v := range values {
+		found := false
+		for _, d := range documented {
+			if coversKey(d, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			undocumented = append(undocumented, v)
+		}
+	}
+
+	stale = make([]string, 0)
+	for _, d := range documented {
+		found := false
+		for _, v := range values {
+			if coversKey(d, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			stale = append(stale, d)
+		}
+	}
+	sort.Strings(stale)
+
+	return undocumented, stale
+}
@@ -16,23 +16,104 @@
 
 package checks
 
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
 type Result struct {
 	// Ok indicates whether the result was successful or not.
 	Ok bool
 	// Reason for the result value.  This is a message indicating
 	// the reason for the value of Ok became true or false.
 	Reason string
+	// Details contains the individual findings supporting the
+	// result, if any.
+	Details []string
+}
+
+// CheckOptions contains the input of a check.
+type CheckOptions struct {
+	// URI is the location of the chart to be checked.
+	URI string
+	// Config contains the configuration specific to the check, as informed in the
+	// config file; it is nil when the check hasn't been configured.
+	Config *viper.Viper
+	// Render contains the options the chart is rendered with; the default ones are used when nil.
+	Render *RenderOptions
+	// RenderCache holds the charts rendered during the run; charts are rendered for every check inspecting them when nil.
+	RenderCache *RenderCache
+}
+
+type CheckFunc func(options *CheckOptions) (Result, error)
+
+// CheckCategory groups related checks.
+type CheckCategory string
+
+const (
+	StructureCategory      CheckCategory = "structure"
+	DocumentationCategory  CheckCategory = "documentation"
+	CompatibilityCategory  CheckCategory = "compatibility"
+	SecurityCategory       CheckCategory = "security"
+	ReliabilityCategory    CheckCategory = "reliability"
+	ClassificationCategory CheckCategory = "classification"
+)
+
+// Severity tells how much the failure of a check matters.
+type Severity string
+
+const (
+	InfoSeverity    Severity = "info"
+	WarningSeverity Severity = "warning"
+	ErrorSeverity   Severity = "error"
+)
+
+var severityRanks = map[Severity]int{
+	InfoSeverity:    0,
+	WarningSeverity: 1,
+	ErrorSeverity:   2,
+}
+
+// ParseSeverity returns the severity of the given name.
+func ParseSeverity(name string) (Severity, error) {
+	s := Severity(name)
+	if _, ok := severityRanks[s]; !ok {
+		return "", errors.Errorf("unknown severity %q, expected one of info, warning or error", name)
+	}
+	return s, nil
+}
+
+// AtLeast tells whether the severity is the same or higher than the given one.
+func (s Severity) AtLeast(other Severity) bool {
+	return severityRanks[s] >= severityRanks[other]
 }
 
-type CheckFunc func(uri string) (Result, error)
+// Check is a check along with the metadata describing it to users.
+type Check struct {
+	// Name identifies the check in the command line, the config file and the certificate.
+	Name string
+	// Description tells what the check verifies.
+	Description string
+	Category    CheckCategory
+	// Remediation tells how to fix a chart failing the check.
+	Remediation string
+	// DocsURL points to the documentation of the check.
+	DocsURL string
+	// Version of the check, bumped whenever its behavior changes.
+	Version string
+	// Severity of the check failing; checks without one are considered errors.
+	Severity Severity
+	// Func performs the check.
+	Func CheckFunc
+}
 
 type Registry interface {
-	Get(name string) (CheckFunc, bool)
-	Add(name string, checkFunc CheckFunc) Registry
+	Get(name string) (Check, bool)
+	Add(check Check) Registry
 	AllChecks() []string
 }
 
-type defaultRegistry map[string]CheckFunc
+type defaultRegistry map[string]Check
 
 func (r *defaultRegistry) AllChecks() []string {
 	allChecks := make([]string, 0)
@@ -46,12 +127,12 @@ func NewRegistry() Registry {
 	return &defaultRegistry{}
 }
 
-func (r *defaultRegistry) Get(name string) (CheckFunc, bool) {
+func (r *defaultRegistry) Get(name string) (Check, bool) {
 	v, ok := (*r)[name]
 	return v, ok
 }
 
-func (r *defaultRegistry) Add(name string, checkFunc CheckFunc) Registry {
-	(*r)[name] = checkFunc
+func (r *defaultRegistry) Add(check Check) Registry {
+	(*r)[check.Name] = check
 	return r
 }
@@ -0,0 +1,461 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RenderReleaseNameConfigKey is the key of the render configuration holding the release name.
+	RenderReleaseNameConfigKey = "releaseName"
+	// RenderNamespaceConfigKey is the key of the render configuration holding the release namespace.
+	RenderNamespaceConfigKey = "namespace"
+	// RenderValuesConfigKey is the key of the render configuration holding the values files to render with.
+	RenderValuesConfigKey = "values"
+	// RenderKubeVersionConfigKey is the key of the render configuration holding the Kubernetes version to render for.
+	RenderKubeVersionConfigKey = "kubeVersion"
+	// RenderAPIVersionsConfigKey is the key of the render configuration holding the API versions available in the
+	// cluster, in addition to the built-in ones.
+	RenderAPIVersionsConfigKey = "apiVersions"
+
+	defaultRenderReleaseName = "RELEASE-NAME"
+	defaultRenderNamespace   = "default"
+	// executionErrorPrefix prefixes the errors raised by the 'required' template function.
+	executionErrorPrefix = "execution error at ("
+	// failFunctionError precedes the message of the errors raised by the 'fail' template function.
+	failFunctionError = "error calling fail: "
+)
+
+// RenderOptions are the inputs a chart is rendered with.
+type RenderOptions struct {
+	ReleaseName string
+	Namespace   string
+	// Values are merged over the default values of the chart.
+	Values       map[string]interface{}
+	Capabilities *chartutil.Capabilities
+}
+
+// DefaultRenderOptions returns the options charts are rendered with unless configured otherwise: their default values
+// and Helm's default capabilities.
+func DefaultRenderOptions() *RenderOptions {
+	return &RenderOptions{
+		ReleaseName:  defaultRenderReleaseName,
+		Namespace:    defaultRenderNamespace,
+		Values:       map[string]interface{}{},
+		Capabilities: chartutil.DefaultCapabilities,
+	}
+}
+
+// NewRenderOptions reads the render options from the given configuration, which might be nil.
+func NewRenderOptions(config *viper.Viper) (*RenderOptions, error) {
+	o := DefaultRenderOptions()
+	if config == nil {
+		return o, nil
+	}
+
+	if name := config.GetString(RenderReleaseNameConfigKey); name != "" {
+		o.ReleaseName = name
+	}
+	if ns := config.GetString(RenderNamespaceConfigKey); ns != "" {
+		o.Namespace = ns
+	}
+
+	values, err := readValuesFiles(config.GetStringSlice(RenderValuesConfigKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading render values file")
+	}
+	o.Values = values
+
+	caps := *chartutil.DefaultCapabilities
+	if kubeVersion := config.GetString(RenderKubeVersionConfigKey); kubeVersion != "" {
+		v, err := semver.NewVersion(kubeVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid render kubeVersion %q", kubeVersion)
+		}
+		caps.KubeVersion = chartutil.KubeVersion{
+			Version: "v" + v.String(),
+			Major:   fmt.Sprint(v.Major()),
+			Minor:   fmt.Sprint(v.Minor()),
+		}
+	}
+	if apiVersions := config.GetStringSlice(RenderAPIVersionsConfigKey); len(apiVersions) > 0 {
+		caps.APIVersions = append(append(chartutil.VersionSet{}, caps.APIVersions...), apiVersions...)
+	}
+	o.Capabilities = &caps
+
+	return o, nil
+}
+
+// TemplateError is the failure to render a single template.
+type TemplateError struct {
+	Template string
+	Err      error
+}
+
+// IsRequiredValue tells whether the template failed because a value required by the chart has not been given.
+func (e TemplateError) IsRequiredValue() bool {
+	return strings.HasPrefix(e.Err.Error(), executionErrorPrefix) && !e.IsFailGuard()
+}
+
+// IsFailGuard tells whether the template failed by calling the 'fail' function, as charts do to reject the values
+// they are given.
+func (e TemplateError) IsFailGuard() bool {
+	return strings.Contains(e.Err.Error(), failFunctionError)
+}
+
+// FailMessage returns the message the template passed to the 'fail' function, or the error when it didn't call it.
+func (e TemplateError) FailMessage() string {
+	msg := e.Err.Error()
+	if i := strings.Index(msg, failFunctionError); i >= 0 {
+		return msg[i+len(failFunctionError):]
+	}
+	return msg
+}
+
+// ObjectKey identifies a rendered object by its group, version, kind and name.
+type ObjectKey struct {
+	GVK  schema.GroupVersionKind
+	Name string
+}
+
+// RenderedChart holds the Kubernetes objects a chart would create, including the CRDs in its crds/ directory, and the
+// failures of the templates that could not be rendered.
+type RenderedChart struct {
+	// Objects are the rendered objects, in the order their templates are rendered.
+	Objects []*unstructured.Unstructured
+	Errors  []TemplateError
+	index   map[ObjectKey]*unstructured.Unstructured
+}
+
+func newRenderedChart() *RenderedChart {
+	return &RenderedChart{
+		Objects: make([]*unstructured.Unstructured, 0),
+		index:   make(map[ObjectKey]*unstructured.Unstructured),
+	}
+}
+
+func (r *RenderedChart) add(objects ...*unstructured.Unstructured) {
+	for _, o := range objects {
+		r.Objects = append(r.Objects, o)
+		key := ObjectKey{GVK: o.GroupVersionKind(), Name: o.GetName()}
+		// objects sharing a name in different namespaces are still listed, only the first one is indexed
+		if _, ok := r.index[key]; !ok {
+			r.index[key] = o
+		}
+	}
+}
+
+// Get returns the rendered object of the given group, version, kind and name.
+func (r *RenderedChart) Get(gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, bool) {
+	o, ok := r.index[ObjectKey{GVK: gvk, Name: name}]
+	return o, ok
+}
+
+// List returns the rendered objects of the given group, version and kind.
+func (r *RenderedChart) List(gvk schema.GroupVersionKind) []*unstructured.Unstructured {
+	objects := make([]*unstructured.Unstructured, 0)
+	for _, o := range r.Objects {
+		if o.GroupVersionKind() == gvk {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+// Err returns the failure of the first template that could not be rendered, if any.
+func (r *RenderedChart) Err() error {
+	if len(r.Errors) > 0 {
+		return r.Errors[0].Err
+	}
+	return nil
+}
+
+// RenderCache holds the charts rendered during a run, by chart uri and render options, so a chart is rendered only
+// once per run regardless of the number of checks inspecting it. It is safe for concurrent use.
+type RenderCache struct {
+	mu     sync.Mutex
+	charts map[string]*RenderedChart
+}
+
+// NewRenderCache returns an empty render cache, meant to be used for a single run.
+func NewRenderCache() *RenderCache {
+	return &RenderCache{charts: map[string]*RenderedChart{}}
+}
+
+// RenderChart renders the chart at the given uri with the given options like RenderChart does, unless it has already
+// been rendered with the same options.
+func (c *RenderCache) RenderChart(uri string, opts *RenderOptions) (*RenderedChart, error) {
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+
+	o, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+	key := uri + "\x00" + string(o)
+
+	// charts are rendered while holding the lock, so concurrent checks don't render the same chart twice
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if rendered, ok := c.charts[key]; ok {
+		return rendered, nil
+	}
+
+	rendered, err := RenderChart(uri, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.charts[key] = rendered
+	return rendered, nil
+}
+
+// RenderChart renders the chart at the given uri with the given options, or the default ones when nil. A failure to
+// render any of its templates is reported in the result rather than as an error, so the objects of the other
+// templates can still be inspected.
+func RenderChart(uri string, opts *RenderOptions) (*RenderedChart, error) {
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+
+	c, _, err := LoadChartFromURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderChart(c, opts)
+}
+
+// renderChart renders the given chart with the given options. When the chart as a whole fails to render, its
+// templates are rendered one at a time so the failure of a template doesn't prevent the others from being inspected.
+func renderChart(c *chart.Chart, opts *RenderOptions) (*RenderedChart, error) {
+	// dependencies are processed as helm install does, leaving out the ones disabled by their condition or tags; this
+	// changes the chart, which is shared with the other checks through the chart cache
+	c = copyChart(c)
+	if err := chartutil.ProcessDependencies(c, opts.Values); err != nil {
+		return nil, err
+	}
+
+	vals, err := chartutil.CoalesceValues(c, opts.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	top := chartutil.Values{
+		"Chart":        c.Metadata,
+		"Capabilities": opts.Capabilities,
+		"Release": map[string]interface{}{
+			"Name":      opts.ReleaseName,
+			"Namespace": opts.Namespace,
+			"IsInstall": true,
+			"IsUpgrade": false,
+			"Revision":  1,
+			"Service":   "Helm",
+		},
+		"Values": vals,
+	}
+
+	result := newRenderedChart()
+
+	rendered, err := engine.Render(c, top)
+	if err != nil {
+		rendered, result.Errors = renderEachTemplate(c, top)
+	}
+
+	for _, crd := range c.CRDObjects() {
+		o, err := parseManifests(crd.Filename, string(crd.File.Data))
+		if err != nil {
+			return nil, err
+		}
+		result.add(o...)
+	}
+
+	files := make([]string, 0, len(rendered))
+	for name := range rendered {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	for _, name := range files {
+		switch path.Ext(name) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		o, err := parseManifests(name, rendered[name])
+		if err != nil {
+			return nil, err
+		}
+		result.add(o...)
+	}
+
+	return result, nil
+}
+
+// copyChart returns a copy of the given chart and its dependencies that can be changed without changing the given
+// chart; the files are shared, being never changed.
+func copyChart(c *chart.Chart) *chart.Chart {
+	cp := *c
+
+	metadata := *c.Metadata
+	metadata.Dependencies = make([]*chart.Dependency, 0, len(c.Metadata.Dependencies))
+	for _, d := range c.Metadata.Dependencies {
+		dependency := *d
+		metadata.Dependencies = append(metadata.Dependencies, &dependency)
+	}
+	cp.Metadata = &metadata
+
+	if c.Values != nil {
+		cp.Values = copyValue(c.Values).(map[string]interface{})
+	}
+
+	dependencies := make([]*chart.Chart, 0, len(c.Dependencies()))
+	for _, d := range c.Dependencies() {
+		dependencies = append(dependencies, copyChart(d))
+	}
+	cp.SetDependencies(dependencies...)
+
+	return &cp
+}
+
+// copyValue returns a deep copy of the given value, as found in the values of a chart.
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = copyValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = copyValue(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// renderEachTemplate renders the templates of the given chart one at a time, along with the partials they might
+// include; the templates of its dependencies are rendered together.
+func renderEachTemplate(c *chart.Chart, top chartutil.Values) (map[string]string, []TemplateError) {
+	var partials, templates []*chart.File
+	for _, t := range c.Templates {
+		if strings.HasPrefix(path.Base(t.Name), "_") {
+			partials = append(partials, t)
+		} else {
+			templates = append(templates, t)
+		}
+	}
+
+	rendered := map[string]string{}
+	var errs []TemplateError
+
+	render := func(name string, partial *chart.Chart) {
+		out, err := engine.Render(partial, top)
+		if err != nil {
+			errs = append(errs, TemplateError{Template: name, Err: err})
+			return
+		}
+		for k, v := range out {
+			rendered[k] = v
+		}
+	}
+
+	for _, t := range templates {
+		partial := *c
+		partial.Templates = append(append([]*chart.File{}, partials...), t)
+		partial.SetDependencies()
+		render(path.Join(c.Name(), t.Name), &partial)
+	}
+
+	if len(c.Dependencies()) > 0 {
+		partial := *c
+		partial.Templates = partials
+		render(path.Join(c.Name(), "charts"), &partial)
+	}
+
+	return rendered, errs
+}
+
+// renderCheckedChart renders the chart being checked with the render options of the run, through the render cache of
+// the run when there is one.
+func renderCheckedChart(opts *CheckOptions) (*RenderedChart, error) {
+	if opts.RenderCache != nil {
+		return opts.RenderCache.RenderChart(opts.URI, opts.Render)
+	}
+	return RenderChart(opts.URI, opts.Render)
+}
+
+// renderManifests renders the chart being checked with the render options of the run, returning the Kubernetes
+// objects it would create; an error is returned if any of its templates fail to render.
+func renderManifests(opts *CheckOptions) ([]*unstructured.Unstructured, error) {
+	rendered, err := renderCheckedChart(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := rendered.Err(); err != nil {
+		return nil, err
+	}
+	return rendered.Objects, nil
+}
+
+// parseManifests parses the documents contained in the given rendered template, skipping empty ones.
+func parseManifests(name, content string) ([]*unstructured.Unstructured, error) {
+	manifests := releaseutil.SplitManifests(content)
+	keys := make([]string, 0, len(manifests))
+	for k := range manifests {
+		keys = append(keys, k)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	objects := make([]*unstructured.Unstructured, 0, len(keys))
+	for _, k := range keys {
+		if strings.TrimSpace(manifests[k]) == "" {
+			continue
+		}
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(manifests[k]), &obj); err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", name)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: obj})
+	}
+	return objects, nil
+}
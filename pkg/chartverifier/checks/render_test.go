@@ -0,0 +1,201 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRenderChart(t *testing.T) {
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	t.Run("default options", func(t *testing.T) {
+		r, err := RenderChart("chart-0.1.0-v3.valid.tgz", nil)
+		require.NoError(t, err)
+		require.NoError(t, r.Err())
+		require.Len(t, r.Objects, 4)
+
+		o, ok := r.Get(deployment, "RELEASE-NAME-chart")
+		require.True(t, ok)
+		require.Equal(t, "Deployment", o.GetKind())
+		require.Equal(t, []string{"RELEASE-NAME-chart"}, objectNames(r.List(deployment)))
+
+		_, ok = r.Get(schema.GroupVersionKind{Group: "apps", Version: "v1beta1", Kind: "Deployment"}, "RELEASE-NAME-chart")
+		require.False(t, ok)
+
+		// charts are rendered again unless rendered through a render cache
+		rendered, err := RenderChart("chart-0.1.0-v3.valid.tgz", DefaultRenderOptions())
+		require.NoError(t, err)
+		require.NotSame(t, r, rendered)
+	})
+
+	t.Run("render cache", func(t *testing.T) {
+		cache := NewRenderCache()
+
+		var wg sync.WaitGroup
+		results := make([]*RenderedChart, 4)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = cache.RenderChart("chart-0.1.0-v3.valid.tgz", nil)
+			}(i)
+		}
+		wg.Wait()
+
+		require.NotNil(t, results[0])
+		for _, r := range results[1:] {
+			require.Same(t, results[0], r)
+		}
+
+		cached, err := cache.RenderChart("chart-0.1.0-v3.valid.tgz", DefaultRenderOptions())
+		require.NoError(t, err)
+		require.Same(t, results[0], cached)
+
+		opts := DefaultRenderOptions()
+		opts.ReleaseName = "demo"
+		other, err := cache.RenderChart("chart-0.1.0-v3.valid.tgz", opts)
+		require.NoError(t, err)
+		require.NotSame(t, results[0], other)
+
+		// another run renders the chart again
+		rendered, err := NewRenderCache().RenderChart("chart-0.1.0-v3.valid.tgz", nil)
+		require.NoError(t, err)
+		require.NotSame(t, results[0], rendered)
+	})
+
+	t.Run("concurrent runs", func(t *testing.T) {
+		// the chart is loaded concurrently, under a uri no other test loads it with
+		var wg sync.WaitGroup
+		results := make([]*RenderedChart, 4)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = NewRenderCache().RenderChart("./chart-0.1.0-v3.valid.tgz", nil)
+			}(i)
+		}
+		wg.Wait()
+
+		for _, r := range results {
+			require.NotNil(t, r)
+			require.Len(t, r.Objects, 4)
+		}
+	})
+
+	t.Run("configured options", func(t *testing.T) {
+		config := viper.New()
+		config.Set(RenderReleaseNameConfigKey, "demo")
+		config.Set(RenderNamespaceConfigKey, "charts")
+		config.Set(RenderValuesConfigKey, []string{"chart-0.1.0-v3.render.values.yaml"})
+		config.Set(RenderKubeVersionConfigKey, "1.13.5")
+		config.Set(RenderAPIVersionsConfigKey, []string{"example.com/v1"})
+
+		opts, err := NewRenderOptions(config)
+		require.NoError(t, err)
+		require.Equal(t, "v1.13.5", opts.Capabilities.KubeVersion.Version)
+		require.True(t, opts.Capabilities.APIVersions.Has("example.com/v1"))
+		require.True(t, opts.Capabilities.APIVersions.Has("apps/v1"))
+
+		r, err := RenderChart("chart-0.1.0-v3.valid.tgz", opts)
+		require.NoError(t, err)
+		require.NoError(t, r.Err())
+
+		require.Equal(t, []string{"demo-chart"}, objectNames(r.List(deployment)))
+		// the ingress API version depends on the kubernetes version it is rendered for
+		ingress := schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}
+		require.Equal(t, []string{"demo-chart"}, objectNames(r.List(ingress)))
+	})
+
+	t.Run("template failures", func(t *testing.T) {
+		r, err := RenderChart("chart-0.1.0-v3.with-prerequisites.tgz", nil)
+		require.NoError(t, err)
+		require.Error(t, r.Err())
+		require.Len(t, r.Errors, 1)
+		require.Equal(t, "chart/templates/database.yaml", r.Errors[0].Template)
+		require.True(t, r.Errors[0].IsRequiredValue())
+		require.False(t, r.Errors[0].IsFailGuard())
+
+		// the objects of the other templates are still rendered
+		_, ok := r.Get(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}, "RELEASE-NAME-chart-worker")
+		require.True(t, ok)
+	})
+
+	t.Run("fail guards", func(t *testing.T) {
+		r, err := RenderChart("chart-0.1.0-v3.with-fail-guard.tgz", nil)
+		require.NoError(t, err)
+		require.Len(t, r.Errors, 1)
+		require.Equal(t, "chart/templates/license.yaml", r.Errors[0].Template)
+		require.True(t, r.Errors[0].IsFailGuard())
+		require.False(t, r.Errors[0].IsRequiredValue())
+		require.Equal(t, "the license must be accepted by setting acceptLicense", r.Errors[0].FailMessage())
+
+		// fail guards are told apart even when reported the way required values are
+		wrapped := TemplateError{Err: errors.New("execution error at (chart/templates/license.yaml:2:4): " +
+			"error calling fail: the license must be accepted")}
+		require.True(t, wrapped.IsFailGuard())
+		require.False(t, wrapped.IsRequiredValue())
+	})
+
+	t.Run("disabled dependencies", func(t *testing.T) {
+		configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+		r, err := RenderChart("chart-0.1.0-v3.dependencies-disabled.tgz", nil)
+		require.NoError(t, err)
+		require.NoError(t, r.Err())
+		require.Empty(t, r.List(configMap))
+
+		// enabling the dependency renders it, without changing the chart shared with the other checks
+		opts := DefaultRenderOptions()
+		opts.Values = map[string]interface{}{"subchart": map[string]interface{}{"enabled": true}}
+		r, err = RenderChart("chart-0.1.0-v3.dependencies-disabled.tgz", opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"RELEASE-NAME-subchart"}, objectNames(r.List(configMap)))
+
+		c, _, err := LoadChartFromURI("chart-0.1.0-v3.dependencies-disabled.tgz")
+		require.NoError(t, err)
+		require.Len(t, c.Dependencies(), 1)
+		require.Len(t, c.Metadata.Dependencies, 1)
+	})
+
+	t.Run("invalid kubeVersion", func(t *testing.T) {
+		config := viper.New()
+		config.Set(RenderKubeVersionConfigKey, "latest")
+		_, err := NewRenderOptions(config)
+		require.Error(t, err)
+	})
+
+	t.Run("non existing chart", func(t *testing.T) {
+		_, err := RenderChart("chart-0.1.0-v3.non-existing.tgz", nil)
+		require.Error(t, err)
+	})
+}
+
+func objectNames(objects []*unstructured.Unstructured) []string {
+	names := make([]string, 0, len(objects))
+	for _, o := range objects {
+		names = append(names, o.GetName())
+	}
+	return names
+}
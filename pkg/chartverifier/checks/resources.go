@@ -0,0 +1,75 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// containerResourceProblems returns the resource settings the given container is missing, followed by the requests
+// exceeding their limits.
+func containerResourceProblems(c corev1.Container) []string {
+	problems := make([]string, 0)
+
+	missing := make([]string, 0)
+	if _, ok := c.Resources.Requests[corev1.ResourceCPU]; !ok {
+		missing = append(missing, "cpu request")
+	}
+	if _, ok := c.Resources.Requests[corev1.ResourceMemory]; !ok {
+		missing = append(missing, "memory request")
+	}
+	if _, ok := c.Resources.Limits[corev1.ResourceMemory]; !ok {
+		missing = append(missing, "memory limit")
+	}
+	if len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ", "))
+	}
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		request, hasRequest := c.Resources.Requests[name]
+		limit, hasLimit := c.Resources.Limits[name]
+		if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+			problems = append(problems,
+				fmt.Sprintf("%s request %s exceeds limit %s", name, request.String(), limit.String()))
+		}
+	}
+
+	return problems
+}
+
+// podSpecResourceProblems returns the resource problems of the init and regular containers of the given pod spec,
+// one entry per container having any.
+func podSpecResourceProblems(spec *corev1.PodSpec) []string {
+	problems := make([]string, 0)
+	add := func(kind string, c corev1.Container) {
+		if p := containerResourceProblems(c); len(p) > 0 {
+			problems = append(problems, fmt.Sprintf("%s %q: %s", kind, c.Name, strings.Join(p, ", ")))
+		}
+	}
+
+	for _, c := range spec.InitContainers {
+		add("init container", c)
+	}
+	for _, c := range spec.Containers {
+		add("container", c)
+	}
+
+	return problems
+}
@@ -0,0 +1,398 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// CustomCategory groups the checks declared in rule files.
+const CustomCategory CheckCategory = "custom"
+
+// RuleSource is the part of the chart a rule inspects.
+type RuleSource string
+
+const (
+	// ChartRuleSource is the Chart.yaml file of the chart.
+	ChartRuleSource RuleSource = "chart"
+	// ValuesRuleSource is the default values of the chart, along with the values it is rendered with.
+	ValuesRuleSource RuleSource = "values"
+	// ObjectsRuleSource is each of the objects of the rendered chart, optionally filtered by kind.
+	ObjectsRuleSource RuleSource = "objects"
+)
+
+// ruleNameRegexp matches the names rules can be given, the same way the built-in checks are named.
+var ruleNameRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// RuleTarget selects what a rule inspects.
+type RuleTarget struct {
+	Source RuleSource `json:"source"`
+	// Kinds restricts the objects a rule targeting objects inspects; all of them are inspected when empty.
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// RuleAssertion is the condition the value at a path must meet. Without any other condition, the path must exist.
+type RuleAssertion struct {
+	// Path is the dot separated path of the value, where keys holding dots are quoted in brackets and list elements
+	// are indexed in brackets, as in metadata.annotations["charts.openshift.io/name"] or spec.ports[0].name.
+	Path string `json:"path"`
+	// Exists set to false asserts the path does not exist.
+	Exists *bool `json:"exists,omitempty"`
+	// Equals asserts the value is equal to the given one.
+	Equals interface{} `json:"equals,omitempty"`
+	// OneOf asserts the value is equal to one of the given ones.
+	OneOf []interface{} `json:"oneOf,omitempty"`
+	// Matches asserts the value, formatted as a string, matches the given regular expression.
+	Matches string `json:"matches,omitempty"`
+}
+
+// Rule is a check declared in a rule file.
+type Rule struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Severity    Severity      `json:"severity,omitempty"`
+	Target      RuleTarget    `json:"target"`
+	Assert      RuleAssertion `json:"assert"`
+	// Message is the reason of the rule failing.
+	Message     string `json:"message"`
+	Remediation string `json:"remediation,omitempty"`
+	DocsURL     string `json:"docs,omitempty"`
+	Version     string `json:"version,omitempty"`
+
+	matches *regexp.Regexp
+	path    []interface{}
+}
+
+// ruleFile is the content of a rule file.
+type ruleFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadRules reads the rules declared in the given rule file.
+func LoadRules(filename string) ([]Rule, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	f := ruleFile{}
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, errors.Wrapf(err, "parsing rule file %s", filename)
+	}
+
+	seen := map[string]bool{}
+	for i := range f.Rules {
+		r := &f.Rules[i]
+		if err := r.compile(); err != nil {
+			return nil, errors.Wrapf(err, "rule file %s", filename)
+		}
+		if seen[r.Name] {
+			return nil, errors.Errorf("rule file %s: rule %q is declared more than once", filename, r.Name)
+		}
+		seen[r.Name] = true
+	}
+
+	return f.Rules, nil
+}
+
+// compile validates the rule, preparing its assertion to be evaluated.
+func (r *Rule) compile() error {
+	if !ruleNameRegexp.MatchString(r.Name) {
+		return errors.Errorf("rule name %q must be lowercase words separated by dashes", r.Name)
+	}
+
+	switch r.Target.Source {
+	case ChartRuleSource, ValuesRuleSource:
+		if len(r.Target.Kinds) > 0 {
+			return errors.Errorf("rule %q: only rules targeting objects can filter them by kind", r.Name)
+		}
+	case ObjectsRuleSource:
+	default:
+		return errors.Errorf("rule %q: unknown target source %q, expected one of chart, values or objects",
+			r.Name, r.Target.Source)
+	}
+
+	if r.Severity != "" {
+		if _, err := ParseSeverity(string(r.Severity)); err != nil {
+			return errors.Wrapf(err, "rule %q", r.Name)
+		}
+	}
+
+	if r.Message == "" {
+		return errors.Errorf("rule %q: message is missing", r.Name)
+	}
+
+	path, err := parseRulePath(r.Assert.Path)
+	if err != nil {
+		return errors.Wrapf(err, "rule %q", r.Name)
+	}
+	r.path = path
+
+	if r.Assert.Matches != "" {
+		if r.matches, err = regexp.Compile(r.Assert.Matches); err != nil {
+			return errors.Wrapf(err, "rule %q", r.Name)
+		}
+	}
+
+	if r.Assert.Exists != nil && !*r.Assert.Exists &&
+		(r.Assert.Equals != nil || len(r.Assert.OneOf) > 0 || r.Assert.Matches != "") {
+		return errors.Errorf("rule %q: a path asserted not to exist can't be compared", r.Name)
+	}
+
+	return nil
+}
+
+// parseRulePath splits the given path into its map keys, as strings, and list indexes, as ints.
+func parseRulePath(p string) ([]interface{}, error) {
+	if p == "" {
+		return nil, errors.New("assertion path is missing")
+	}
+
+	path := make([]interface{}, 0)
+	for i := 0; i < len(p); {
+		switch {
+		case p[i] == '[':
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, errors.Errorf("unterminated bracket in path %q", p)
+			}
+			inner := p[i+1 : i+end]
+			if unquoted, err := strconv.Unquote(inner); err == nil {
+				path = append(path, unquoted)
+			} else if index, err := strconv.Atoi(inner); err == nil && index >= 0 {
+				path = append(path, index)
+			} else {
+				return nil, errors.Errorf("invalid bracket %q in path %q", inner, p)
+			}
+			i += end + 1
+		case p[i] == '.':
+			if i == 0 || i+1 == len(p) || p[i+1] == '.' || p[i+1] == '[' {
+				return nil, errors.Errorf("misplaced dot in path %q", p)
+			}
+			i++
+		default:
+			end := strings.IndexAny(p[i:], ".[")
+			if end < 0 {
+				end = len(p) - i
+			}
+			path = append(path, p[i:i+end])
+			i += end
+		}
+	}
+	return path, nil
+}
+
+// lookupRulePath returns the value at the given path of the given document.
+func lookupRulePath(doc interface{}, path []interface{}) (interface{}, bool) {
+	v := doc
+	for _, p := range path {
+		switch key := p.(type) {
+		case string:
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[key]; !ok {
+				return nil, false
+			}
+		case int:
+			l, ok := v.([]interface{})
+			if !ok || key >= len(l) {
+				return nil, false
+			}
+			v = l[key]
+		}
+	}
+	return v, true
+}
+
+// ruleValuesEqual tells whether the given document value equals the expected one, as declared in the rule file.
+func ruleValuesEqual(actual, expected interface{}) bool {
+	if reflect.DeepEqual(actual, expected) {
+		return true
+	}
+	// numbers are decoded as float64 from the rule file but might be int64 in rendered objects
+	return fmt.Sprint(actual) == fmt.Sprint(expected) && reflect.TypeOf(actual) != reflect.TypeOf("") &&
+		reflect.TypeOf(expected) != reflect.TypeOf("")
+}
+
+// evaluate tells why the given document doesn't meet the rule assertion, or returns an empty string when it does.
+func (r *Rule) evaluate(doc interface{}) string {
+	a := r.Assert
+	v, found := lookupRulePath(doc, r.path)
+
+	if a.Exists != nil && !*a.Exists {
+		if found {
+			return fmt.Sprintf("%s is set", a.Path)
+		}
+		return ""
+	}
+	if !found {
+		return fmt.Sprintf("%s is not set", a.Path)
+	}
+
+	if a.Equals != nil && !ruleValuesEqual(v, a.Equals) {
+		return fmt.Sprintf("%s is %v, expected %v", a.Path, v, a.Equals)
+	}
+
+	if len(a.OneOf) > 0 {
+		oneOf := false
+		for _, o := range a.OneOf {
+			if ruleValuesEqual(v, o) {
+				oneOf = true
+				break
+			}
+		}
+		if !oneOf {
+			return fmt.Sprintf("%s is %v, expected one of %v", a.Path, v, a.OneOf)
+		}
+	}
+
+	if r.matches != nil && !r.matches.MatchString(fmt.Sprint(v)) {
+		return fmt.Sprintf("%s is %v, which does not match %q", a.Path, v, a.Matches)
+	}
+
+	return ""
+}
+
+// Check returns the check running the rule.
+func (r Rule) Check() Check {
+	severity := r.Severity
+	if severity == "" {
+		severity = ErrorSeverity
+	}
+	version := r.Version
+	if version == "" {
+		version = "1.0"
+	}
+	description := r.Description
+	if description == "" {
+		description = "Checks whether the chart satisfies the " + r.Name + " rule"
+	}
+	return Check{
+		Name:        r.Name,
+		Description: description,
+		Category:    CustomCategory,
+		Remediation: r.Remediation,
+		DocsURL:     r.DocsURL,
+		Version:     version,
+		Severity:    severity,
+		Func:        r.run,
+	}
+}
+
+func (r Rule) run(opts *CheckOptions) (Result, error) {
+	c, _, err := LoadChartFromURI(opts.URI)
+	if err != nil {
+		return Result{}, err
+	}
+
+	ok := Result{Ok: true, Reason: RuleIsSatisfiedPrefix + r.Name}
+	failed := Result{Reason: r.Message}
+
+	switch r.Target.Source {
+	case ChartRuleSource:
+		doc, err := chartFileDocument(c)
+		if err != nil {
+			return Result{}, err
+		}
+		if problem := r.evaluate(doc); problem != "" {
+			failed.Details = []string{chartutil.ChartfileName + ": " + problem}
+			return failed, nil
+		}
+		return ok, nil
+
+	case ValuesRuleSource:
+		render := opts.Render
+		if render == nil {
+			render = DefaultRenderOptions()
+		}
+		vals, err := chartutil.CoalesceValues(c, render.Values)
+		if err != nil {
+			return Result{}, err
+		}
+		if problem := r.evaluate(map[string]interface{}(vals)); problem != "" {
+			failed.Details = []string{"values: " + problem}
+			return failed, nil
+		}
+		return ok, nil
+
+	default:
+		objects, err := renderManifests(opts)
+		if err != nil {
+			return Result{Reason: ChartRenderFailedPrefix + err.Error()}, nil
+		}
+		for _, o := range r.targetObjects(objects) {
+			if problem := r.evaluate(o.Object); problem != "" {
+				failed.Details = append(failed.Details, fmt.Sprintf("%s %s", objectName(o), problem))
+			}
+		}
+		if len(failed.Details) > 0 {
+			return failed, nil
+		}
+		return ok, nil
+	}
+}
+
+// targetObjects returns the given objects of the kinds the rule targets.
+func (r Rule) targetObjects(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	if len(r.Target.Kinds) == 0 {
+		return objects
+	}
+	targets := make([]*unstructured.Unstructured, 0)
+	for _, o := range objects {
+		if contains(r.Target.Kinds, o.GetKind()) {
+			targets = append(targets, o)
+		}
+	}
+	return targets
+}
+
+// chartFileDocument returns the content of the Chart.yaml file of the given chart, including the fields Helm ignores.
+func chartFileDocument(c *chart.Chart) (map[string]interface{}, error) {
+	data := []byte(nil)
+	for _, f := range c.Raw {
+		if f.Name == chartutil.ChartfileName {
+			data = f.Data
+			break
+		}
+	}
+	// charts not loaded from files only have their parsed metadata
+	if data == nil {
+		var err error
+		if data, err = yaml.Marshal(c.Metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", chartutil.ChartfileName)
+	}
+	return doc, nil
+}
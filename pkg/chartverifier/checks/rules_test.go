@@ -0,0 +1,257 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRulePath(t *testing.T) {
+	positiveTestCases := []struct {
+		path     string
+		expected []interface{}
+	}{
+		{path: "icon", expected: []interface{}{"icon"}},
+		{path: "metadata.labels.team", expected: []interface{}{"metadata", "labels", "team"}},
+		{
+			path:     `annotations["charts.openshift.io/name"]`,
+			expected: []interface{}{"annotations", "charts.openshift.io/name"},
+		},
+		{path: "spec.ports[0].port", expected: []interface{}{"spec", "ports", 0, "port"}},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.path, func(t *testing.T) {
+			path, err := parseRulePath(tc.path)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, path)
+		})
+	}
+
+	negativeTestCases := []string{"", ".icon", "icon.", "metadata..labels", "ports[0", "ports[-1]", "ports[first]"}
+
+	for _, p := range negativeTestCases {
+		t.Run(p, func(t *testing.T) {
+			_, err := parseRulePath(p)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		rules, err := LoadRules("rules.yaml")
+		require.NoError(t, err)
+		require.Len(t, rules, 9)
+
+		check := rules[0].Check()
+		require.Equal(t, "chart-icon-is-served-over-https", check.Name)
+		require.Equal(t, CustomCategory, check.Category)
+		require.Equal(t, WarningSeverity, check.Severity)
+		require.Equal(t, "1.0", check.Version)
+		require.Equal(t, "Serve the icon over HTTPS", check.Remediation)
+
+		// severity defaults to error
+		require.Equal(t, ErrorSeverity, rules[1].Check().Severity)
+	})
+
+	t.Run("invalid rule file", func(t *testing.T) {
+		_, err := LoadRules("rules-invalid.yaml")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rule file rules-invalid.yaml")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadRules("rules-non-existing.yaml")
+		require.Error(t, err)
+	})
+
+	negativeTestCases := []struct {
+		description string
+		content     string
+		expected    string
+	}{
+		{
+			description: "unknown field",
+			content:     "rules:\n- name: a\n  target: {source: chart}\n  assert: {path: icon}\n  message: m\n  unknown: x\n",
+			expected:    "unknown field",
+		},
+		{
+			description: "invalid name",
+			content:     "rules:\n- name: A Rule\n  target: {source: chart}\n  assert: {path: icon}\n  message: m\n",
+			expected:    "lowercase words",
+		},
+		{
+			description: "unknown source",
+			content:     "rules:\n- name: a\n  target: {source: readme}\n  assert: {path: icon}\n  message: m\n",
+			expected:    "unknown target source",
+		},
+		{
+			description: "kinds of chart source",
+			content:     "rules:\n- name: a\n  target: {source: chart, kinds: [Pod]}\n  assert: {path: icon}\n  message: m\n",
+			expected:    "filter them by kind",
+		},
+		{
+			description: "missing message",
+			content:     "rules:\n- name: a\n  target: {source: chart}\n  assert: {path: icon}\n",
+			expected:    "message is missing",
+		},
+		{
+			description: "missing path",
+			content:     "rules:\n- name: a\n  target: {source: chart}\n  assert: {equals: x}\n  message: m\n",
+			expected:    "assertion path is missing",
+		},
+		{
+			description: "invalid regular expression",
+			content:     "rules:\n- name: a\n  target: {source: chart}\n  assert: {path: icon, matches: '('}\n  message: m\n",
+			expected:    "missing closing )",
+		},
+		{
+			description: "unknown severity",
+			content:     "rules:\n- name: a\n  severity: fatal\n  target: {source: chart}\n  assert: {path: icon}\n  message: m\n",
+			expected:    "fatal",
+		},
+		{
+			description: "comparing a missing path",
+			content:     "rules:\n- name: a\n  target: {source: chart}\n  assert: {path: icon, exists: false, equals: x}\n  message: m\n",
+			expected:    "can't be compared",
+		},
+		{
+			description: "duplicate rule",
+			content: "rules:\n- name: a\n  target: {source: chart}\n  assert: {path: icon}\n  message: m\n" +
+				"- name: a\n  target: {source: chart}\n  assert: {path: type}\n  message: m\n",
+			expected: "declared more than once",
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "rules.yaml")
+			require.NoError(t, ioutil.WriteFile(filename, []byte(tc.content), 0644))
+
+			_, err := LoadRules(filename)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	rules, err := LoadRules("rules.yaml")
+	require.NoError(t, err)
+	byName := map[string]Rule{}
+	for _, r := range rules {
+		byName[r.Name] = r
+	}
+
+	type testCase struct {
+		description string
+		rule        string
+		uri         string
+		values      []string
+		ok          bool
+		reason      string
+		details     []string
+	}
+
+	positiveTestCases := []testCase{
+		{description: "chart field matches", rule: "chart-icon-is-served-over-https", uri: "chart-0.1.0-v3.valid.tgz"},
+		{description: "chart field is one of", rule: "chart-type-is-application", uri: "chart-0.1.0-v3.valid.tgz"},
+		{description: "default value equals", rule: "service-is-cluster-ip", uri: "chart-0.1.0-v3.valid.tgz"},
+		{
+			description: "rendered value equals",
+			rule:        "ingress-is-enabled",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			values:      []string{"chart-0.1.0-v3.render.values.yaml"},
+		},
+		{description: "every object matches", rule: "objects-are-managed-by-helm", uri: "chart-0.1.0-v3.valid.tgz"},
+		{description: "indexed number equals", rule: "services-listen-on-port-80", uri: "chart-0.1.0-v3.valid.tgz"},
+		{description: "path does not exist", rule: "pods-do-not-use-host-network", uri: "chart-0.1.0-v3.valid.tgz"},
+	}
+
+	for _, tc := range positiveTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := runRule(byName[tc.rule], tc.uri, tc.values)
+			require.NoError(t, err)
+			require.True(t, r.Ok)
+			require.Equal(t, RuleIsSatisfiedPrefix+tc.rule, r.Reason)
+		})
+	}
+
+	negativeTestCases := []testCase{
+		{
+			description: "chart annotation is not set",
+			rule:        "chart-has-name-annotation",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			reason:      "Chart.yaml does not set the charts.openshift.io/name annotation",
+			details:     []string{`Chart.yaml: annotations["charts.openshift.io/name"] is not set`},
+		},
+		{
+			description: "default value differs",
+			rule:        "ingress-is-enabled",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			reason:      "Ingress is not enabled by default",
+			details:     []string{"values: ingress.enabled is false, expected true"},
+		},
+		{
+			description: "object label is not set",
+			rule:        "deployments-have-team-label",
+			uri:         "chart-0.1.0-v3.valid.tgz",
+			reason:      "Deployments do not have the team label",
+			details:     []string{"Deployment/RELEASE-NAME-chart metadata.labels.team is not set"},
+		},
+		{
+			description: "chart does not render",
+			rule:        "deployments-have-team-label",
+			uri:         "chart-0.1.0-v3.with-prerequisites.tgz",
+			reason:      ChartRenderFailedPrefix,
+		},
+	}
+
+	for _, tc := range negativeTestCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := runRule(byName[tc.rule], tc.uri, tc.values)
+			require.NoError(t, err)
+			require.False(t, r.Ok)
+			require.Contains(t, r.Reason, tc.reason)
+			if tc.details != nil {
+				require.Equal(t, tc.details, r.Details)
+			}
+		})
+	}
+
+	t.Run("missing chart", func(t *testing.T) {
+		_, err := runRule(byName["chart-type-is-application"], "chart-0.1.0-v3.non-existing.tgz", nil)
+		require.Error(t, err)
+	})
+}
+
+// runRule runs the check of the given rule, rendering the chart with the given values files.
+func runRule(rule Rule, uri string, values []string) (Result, error) {
+	config := viper.New()
+	config.Set(RenderValuesConfigKey, values)
+	render, err := NewRenderOptions(config)
+	if err != nil {
+		return Result{}, err
+	}
+	return rule.Check().Func(&CheckOptions{URI: uri, Render: render})
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	sccAPIGroup = "security.openshift.io"
+	sccResource = "securitycontextconstraints"
+	sccUseVerb  = "use"
+	// sccClusterRolePrefix prefixes the names of the cluster roles OpenShift provides to grant the use of each SCC.
+	sccClusterRolePrefix = "system:openshift:scc:"
+
+	runAsUserRemediation = "remove it, or make it optional in the values, so OpenShift can assign an arbitrary UID " +
+		"from the namespace range; the image must run as any non-root UID belonging to the root group"
+	fsGroupRemediation = "remove it, or make it optional in the values, so OpenShift can assign a group from the " +
+		"namespace range"
+	seLinuxRemediation = "remove them so OpenShift can apply the MCS labels of the namespace"
+	sccUseRemediation  = "remove it and let the chart run under the restricted SCC; when more privileges are really " +
+		"required, document the SCC the cluster administrator must grant to the service account instead"
+)
+
+// restrictedSCCProblems returns the settings of the given pod spec preventing its pods from being admitted by the
+// OpenShift restricted SCC, along with how to fix them: first those set at the pod level, then those of each init and
+// regular container, each entry prefixed by what sets them.
+func restrictedSCCProblems(spec *corev1.PodSpec) []string {
+	problems := make([]string, 0)
+
+	if sc := spec.SecurityContext; sc != nil {
+		if sc.RunAsUser != nil {
+			problems = append(problems, fmt.Sprintf("pod: runAsUser is hardcoded to %d; %s", *sc.RunAsUser,
+				runAsUserRemediation))
+		}
+		if sc.FSGroup != nil {
+			problems = append(problems, fmt.Sprintf("pod: fsGroup is hardcoded to %d; %s", *sc.FSGroup,
+				fsGroupRemediation))
+		}
+		if sc.SELinuxOptions != nil {
+			problems = append(problems, fmt.Sprintf("pod: seLinuxOptions are hardcoded to %s; %s",
+				seLinuxOptionsString(sc.SELinuxOptions), seLinuxRemediation))
+		}
+	}
+
+	add := func(kind string, c corev1.Container) {
+		sc := c.SecurityContext
+		if sc == nil {
+			return
+		}
+		if sc.RunAsUser != nil {
+			problems = append(problems, fmt.Sprintf("%s %q: runAsUser is hardcoded to %d; %s", kind, c.Name,
+				*sc.RunAsUser, runAsUserRemediation))
+		}
+		if sc.SELinuxOptions != nil {
+			problems = append(problems, fmt.Sprintf("%s %q: seLinuxOptions are hardcoded to %s; %s", kind, c.Name,
+				seLinuxOptionsString(sc.SELinuxOptions), seLinuxRemediation))
+		}
+	}
+	for _, c := range spec.InitContainers {
+		add("init container", c)
+	}
+	for _, c := range spec.Containers {
+		add("container", c)
+	}
+
+	return problems
+}
+
+func seLinuxOptionsString(o *corev1.SELinuxOptions) string {
+	fields := make([]string, 0, 4)
+	for _, f := range []struct{ name, value string }{
+		{"user", o.User}, {"role", o.Role}, {"type", o.Type}, {"level", o.Level},
+	} {
+		if f.value != "" {
+			fields = append(fields, fmt.Sprintf("%s=%q", f.name, f.value))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// sccUseGrants returns the SCCs the given Role or ClusterRole grants the use of, or the given RoleBinding or
+// ClusterRoleBinding binds through the cluster roles OpenShift provides for each SCC, along with how to fix it; ok is
+// false when the object is neither a role nor a binding.
+func sccUseGrants(obj *unstructured.Unstructured) (grants []string, ok bool, err error) {
+	switch obj.GetKind() {
+	case "Role", "ClusterRole":
+	case "RoleBinding", "ClusterRoleBinding":
+		return sccBindingGrants(obj)
+	default:
+		return nil, false, nil
+	}
+
+	role := &rbacv1.ClusterRole{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, role); err != nil {
+		return nil, true, errors.Wrapf(err, "reading rules of %s", objectName(obj))
+	}
+
+	grants = make([]string, 0)
+	for _, rule := range role.Rules {
+		if !matchesAny(rule.APIGroups, sccAPIGroup) || !matchesAny(rule.Resources, sccResource) ||
+			!matchesAny(rule.Verbs, sccUseVerb) {
+			continue
+		}
+		sccs := "every SCC"
+		if len(rule.ResourceNames) > 0 {
+			sccs = fmt.Sprintf("SCCs %q", rule.ResourceNames)
+		}
+		grants = append(grants, fmt.Sprintf("grants the use of %s; %s", sccs, sccUseRemediation))
+	}
+
+	return grants, true, nil
+}
+
+// sccBindingGrants returns the SCC the given RoleBinding or ClusterRoleBinding grants the use of by binding one of the
+// system:openshift:scc: cluster roles, along with how to fix it.
+func sccBindingGrants(obj *unstructured.Unstructured) (grants []string, ok bool, err error) {
+	binding := &rbacv1.ClusterRoleBinding{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, binding); err != nil {
+		return nil, true, errors.Wrapf(err, "reading role of %s", objectName(obj))
+	}
+
+	ref := binding.RoleRef
+	if ref.Kind != "ClusterRole" || ref.APIGroup != rbacv1.GroupName ||
+		!strings.HasPrefix(ref.Name, sccClusterRolePrefix) {
+		return []string{}, true, nil
+	}
+
+	subjects := make([]string, 0, len(binding.Subjects))
+	for _, s := range binding.Subjects {
+		subjects = append(subjects, s.Kind+"/"+s.Name)
+	}
+	grant := fmt.Sprintf("grants the use of SCC %q to %s through ClusterRole %q; %s",
+		strings.TrimPrefix(ref.Name, sccClusterRolePrefix), strings.Join(subjects, ", "), ref.Name, sccUseRemediation)
+	return []string{grant}, true, nil
+}
+
+// matchesAny tells whether the given RBAC rule values include value, either explicitly or through a wildcard.
+func matchesAny(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == rbacv1.ResourceAll {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	hookAnnotation = release.HookAnnotation
+	// legacyTestHook is the Helm 2 test hook, still run by Helm 3.
+	legacyTestHook = "test-success"
+)
+
+// isTestHook tells whether the given object is annotated as a Helm test hook.
+func isTestHook(obj *unstructured.Unstructured) bool {
+	for _, h := range strings.Split(obj.GetAnnotations()[hookAnnotation], ",") {
+		switch strings.TrimSpace(h) {
+		case string(release.HookTest), legacyTestHook:
+			return true
+		}
+	}
+	return false
+}
+
+// testHookProblem returns why the given test hook can't run as a test: only Pods and Jobs with containers can.
+func testHookProblem(obj *unstructured.Unstructured) (string, error) {
+	if obj.GetKind() != "Pod" && obj.GetKind() != "Job" {
+		return fmt.Sprintf("%s is annotated as a test hook but is neither a Pod nor a Job", objectName(obj)), nil
+	}
+
+	spec, _, err := podSpecOf(obj)
+	if err != nil {
+		return "", err
+	}
+	if len(spec.Containers) == 0 {
+		return fmt.Sprintf("%s is annotated as a test hook but has no containers", objectName(obj)), nil
+	}
+
+	return "", nil
+}
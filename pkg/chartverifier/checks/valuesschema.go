@@ -0,0 +1,97 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	// schemaRootField is the field gojsonschema uses to identify the root of the validated document.
+	schemaRootField = "(root)"
+	// schemaKeyword is the keyword declaring the meta-schema a schema is written against.
+	schemaKeyword = "$schema"
+)
+
+// bundledMetaSchemas maps the meta-schemas bundled with gojsonschema, without scheme nor fragment, to their canonical
+// identifiers.
+var bundledMetaSchemas = map[string]string{
+	"json-schema.org/draft-04/schema": "http://json-schema.org/draft-04/schema#",
+	"json-schema.org/draft-06/schema": "http://json-schema.org/draft-06/schema#",
+	"json-schema.org/draft-07/schema": "http://json-schema.org/draft-07/schema#",
+}
+
+// compileValuesSchema compiles the given values schema, validating it against the JSON Schema meta-schema it declares.
+// Meta-schemas are never retrieved from the network: the declared meta-schema is replaced by its bundled equivalent,
+// or dropped when there isn't one.
+func compileValuesSchema(schema []byte) (*gojsonschema.Schema, error) {
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, err
+	}
+
+	if declared, ok := doc[schemaKeyword].(string); ok {
+		key := strings.TrimSuffix(declared, "#")
+		key = strings.TrimPrefix(strings.TrimPrefix(key, "http://"), "https://")
+		if canonical, ok := bundledMetaSchemas[key]; ok {
+			doc[schemaKeyword] = canonical
+		} else {
+			delete(doc, schemaKeyword)
+		}
+	}
+
+	loader := gojsonschema.NewSchemaLoader()
+	loader.Validate = true
+	return loader.Compile(gojsonschema.NewGoLoader(doc))
+}
+
+// valuesSchemaViolations validates the given values against the given compiled schema, returning each violation
+// prefixed with the JSON path of the offending value.
+func valuesSchemaViolations(schema *gojsonschema.Schema, values map[string]interface{}) ([]string, error) {
+	result, err := schema.Validate(gojsonschema.NewGoLoader(values))
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, e := range result.Errors() {
+		violations = append(violations, fmt.Sprintf("%s: %s", jsonPath(e.Field()), e.Description()))
+	}
+	return violations, nil
+}
+
+// jsonPath converts the dot separated field reported by gojsonschema into a JSON path expression, such as
+// $.ingress.hosts[0].host.
+func jsonPath(field string) string {
+	path := "$"
+	if field == schemaRootField || field == "" {
+		return path
+	}
+	for _, f := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(f); err == nil {
+			path += "[" + f + "]"
+		} else {
+			path += "." + f
+		}
+	}
+	return path
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checks
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// podSpecPaths maps the kinds carrying pods to the path of their pod spec.
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"PodTemplate":           {"template", "spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// podSpecOf returns the pod spec of the given object; ok is false when the object is not a workload.
+func podSpecOf(obj *unstructured.Unstructured) (spec *corev1.PodSpec, ok bool, err error) {
+	fields, ok := podSpecPaths[obj.GetKind()]
+	if !ok {
+		return nil, false, nil
+	}
+
+	m, found, err := unstructured.NestedMap(obj.Object, fields...)
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "reading pod spec of %s", objectName(obj))
+	}
+	if !found {
+		return &corev1.PodSpec{}, true, nil
+	}
+
+	spec = &corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, spec); err != nil {
+		return nil, true, errors.Wrapf(err, "reading pod spec of %s", objectName(obj))
+	}
+	return spec, true, nil
+}
+
+// objectName returns the kind and name of the given object, the way it is identified in check results.
+func objectName(obj *unstructured.Unstructured) string {
+	return obj.GetKind() + "/" + obj.GetName()
+}
+
+// containerImage is the image used by a container of a workload.
+type containerImage struct {
+	Container string
+	Image     string
+}
+
+// podSpecImages returns the images used by the init, regular and ephemeral containers of the given pod spec.
+func podSpecImages(spec *corev1.PodSpec) []containerImage {
+	images := make([]containerImage, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+	for _, c := range spec.InitContainers {
+		images = append(images, containerImage{Container: c.Name, Image: c.Image})
+	}
+	for _, c := range spec.Containers {
+		images = append(images, containerImage{Container: c.Name, Image: c.Image})
+	}
+	for _, c := range spec.EphemeralContainers {
+		images = append(images, containerImage{Container: c.Name, Image: c.Image})
+	}
+	return images
+}
@@ -0,0 +1,292 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chartverifier
+
+import (
+	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+)
+
+const (
+	// checksDocsURL points to the documentation of the checks.
+	checksDocsURL = "https://github.com/redhat-certification/chart-verifier#checks"
+	// checkConfigurationDocsURL points to the documentation of the configuration of the checks.
+	checkConfigurationDocsURL = "https://github.com/redhat-certification/chart-verifier#check-configuration"
+)
+
+// defaultChecks are the checks available by default, in the order they are documented.
+var defaultChecks = []checks.Check{
+	{
+		Name:        "is-helm-v3",
+		Description: "Checks whether the chart is a Helm v3 chart",
+		Category:    checks.StructureCategory,
+		Remediation: "Set apiVersion to v2 in Chart.yaml, moving the requirements.yaml dependencies into Chart.yaml",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.IsHelmV3,
+	},
+	{
+		Name:        "has-readme",
+		Description: "Checks whether the chart contains a README.md file",
+		Category:    checks.DocumentationCategory,
+		Remediation: "Add a README.md file describing the chart and its values",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.HasReadme,
+	},
+	{
+		Name:        "contains-test",
+		Description: "Checks whether the rendered chart contains Helm test hook Pods or Jobs",
+		Category:    checks.StructureCategory,
+		Remediation: "Add a Pod or Job annotated with helm.sh/hook: test running the chart tests",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.ContainsTest,
+	},
+	{
+		Name:        "has-minkubeversion",
+		Description: "Checks whether the chart declares a valid kubeVersion with a lower bound",
+		Category:    checks.CompatibilityCategory,
+		Remediation: "Set kubeVersion in Chart.yaml to a satisfiable constraint with a minimum version, such as >=1.20.0",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.HasMinKubeVersion,
+	},
+	{
+		Name:        "readme-contains-values-schema",
+		Description: "Checks whether the chart README documents every chart value and only existing ones",
+		Category:    checks.DocumentationCategory,
+		Remediation: "Document every value of values.yaml in a values table of README.md, removing the stale keys",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.ReadmeContainsValuesSchema,
+	},
+	{
+		Name:        "contains-values",
+		Description: "Checks whether the chart contains a values.yaml file",
+		Category:    checks.StructureCategory,
+		Remediation: "Add a values.yaml file holding the default values of the chart",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.ContainsValues,
+	},
+	{
+		Name:        "contains-values-schema",
+		Description: "Checks whether the chart contains a values.schema.json file",
+		Category:    checks.StructureCategory,
+		Remediation: "Add a values.schema.json file describing the chart values as a JSON Schema",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.ContainsValuesSchema,
+	},
+	{
+		Name:        "not-contains-crds",
+		Description: "Checks whether the chart does not contain CRDs",
+		Category:    checks.CompatibilityCategory,
+		Remediation: "Move the CRDs to a separate chart or operator installed beforehand",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.NotContainCRDs,
+	},
+	{
+		Name:        "helm-lint",
+		Description: "Checks whether helm lint succeeds for the chart",
+		Category:    checks.StructureCategory,
+		Remediation: "Fix the reported lint messages; run helm lint with the same values to reproduce them",
+		DocsURL:     checkConfigurationDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.HelmLint,
+	},
+	{
+		Name:        "keywords-are-openshift-categories",
+		Description: "Checks whether the chart keywords map to OpenShift categories",
+		Category:    checks.DocumentationCategory,
+		Remediation: "Add keywords matching OpenShift categories to Chart.yaml",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.KeywordsAreOpenshiftCategories,
+	},
+	{
+		Name:        "is-commercial-chart",
+		Description: "Checks whether the chart is a commercial chart",
+		Category:    checks.ClassificationCategory,
+		Remediation: "Set the charts.openshift.io/providerType annotation to partner or redhat and use images from certified registries",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.InfoSeverity,
+		Func:        checks.IsCommercialChart,
+	},
+	{
+		Name:        "is-community-chart",
+		Description: "Checks whether the chart is a community chart",
+		Category:    checks.ClassificationCategory,
+		Remediation: "No action is needed for commercial charts; disable this check for them",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.InfoSeverity,
+		Func:        checks.IsCommunityChart,
+	},
+	{
+		Name:        "not-contains-infra-plugins-and-drivers",
+		Description: "Checks whether the rendered chart does not include infra plugins and drivers",
+		Category:    checks.SecurityCategory,
+		Remediation: "Deliver CSI drivers, storage classes, CNI and device plugins through an operator instead",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.NotContainsInfraPluginsAndDrivers,
+	},
+	{
+		Name:        "can-be-installed-without-cluster-admin-privileges",
+		Description: "Checks whether the rendered chart does not create cluster-scoped objects",
+		Category:    checks.SecurityCategory,
+		Remediation: "Replace the reported cluster-scoped objects with namespaced ones, such as Roles and RoleBindings",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.CanBeInstalledWithoutClusterAdminPrivileges,
+	},
+	{
+		Name:        "can-be-installed-without-manual-prerequisites",
+		Description: "Checks whether the rendered chart creates every object its workloads refer to",
+		Category:    checks.CompatibilityCategory,
+		Remediation: "Create the reported objects in the chart, mark their references optional, or default the required values",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.CanBeInstalledWithoutManualPreRequisites,
+	},
+	{
+		Name:        "images-are-pinned-by-digest",
+		Description: "Checks whether every image used by the rendered chart is pinned by digest",
+		Category:    checks.SecurityCategory,
+		Remediation: "Reference the reported images by their @sha256: digest",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.ImagesArePinnedByDigest,
+	},
+	{
+		Name:        "images-are-from-allowed-registries",
+		Description: "Checks whether every image used by the rendered chart is pulled from an allowed registry",
+		Category:    checks.SecurityCategory,
+		Remediation: "Pull the reported images from an allowed registry, or allow their registry in the configuration",
+		DocsURL:     checkConfigurationDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.ImagesAreFromAllowedRegistries,
+	},
+	{
+		Name:        "values-are-valid-against-schema",
+		Description: "Checks whether the chart default values are valid against its values schema",
+		Category:    checks.StructureCategory,
+		Remediation: "Fix the reported values, or the values schema when it is itself wrong",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.ValuesAreValidAgainstSchema,
+	},
+	{
+		Name:        "containers-set-resource-requests-and-limits",
+		Description: "Checks whether every container of the rendered chart sets resource requests and limits",
+		Category:    checks.ReliabilityCategory,
+		Remediation: "Set CPU and memory requests and a memory limit, not lower than the requests, on the reported containers",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.ContainersSetResourceRequestsAndLimits,
+	},
+	{
+		Name:        "pods-meet-pod-security-standards",
+		Description: "Checks whether every pod of the rendered chart meets the Pod Security Standards",
+		Category:    checks.SecurityCategory,
+		Remediation: "Fix the reported security context settings of the pods and containers",
+		DocsURL:     checkConfigurationDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.PodsMeetPodSecurityStandards,
+	},
+	{
+		Name:        "is-restricted-scc-compatible",
+		Description: "Checks whether the rendered chart can run under the OpenShift restricted SCC",
+		Category:    checks.SecurityCategory,
+		Remediation: "Apply the fix reported with each problem",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.IsRestrictedSCCCompatible,
+	},
+	{
+		Name:        "apis-are-available-in-kubeversion-range",
+		Description: "Checks whether the rendered chart APIs are available across its kubeVersion range",
+		Category:    checks.CompatibilityCategory,
+		Remediation: "Move the reported objects to the replacement API, or narrow the kubeVersion range",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.APIsAreAvailableInKubeVersionRange,
+	},
+	{
+		Name:        "has-complete-metadata",
+		Description: "Checks whether Chart.yaml has every required and well-formed metadata field",
+		Category:    checks.DocumentationCategory,
+		Remediation: "Add or fix the reported Chart.yaml fields and annotations",
+		DocsURL:     checkConfigurationDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.HasCompleteMetadata,
+	},
+	{
+		Name:        "dependencies-are-locked-and-vendored",
+		Description: "Checks whether the chart dependencies are pinned, locked and vendored",
+		Category:    checks.StructureCategory,
+		Remediation: "Pin the dependency versions and run helm dependency update before packaging the chart",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.ErrorSeverity,
+		Func:        checks.DependenciesAreLockedAndVendored,
+	},
+	{
+		Name:        "containers-have-probes",
+		Description: "Checks whether the long-running containers of the rendered chart have readiness and liveness probes",
+		Category:    checks.ReliabilityCategory,
+		Remediation: "Add readiness and liveness probes targeting ports the containers declare",
+		DocsURL:     checksDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.ContainersHaveProbes,
+	},
+	{
+		Name:        "has-verified-provenance",
+		Description: "Checks whether the chart archive is signed by a known publisher",
+		Category:    checks.SecurityCategory,
+		Remediation: "Package the chart with helm package --sign, publishing the .prov file next to the archive",
+		DocsURL:     checkConfigurationDocsURL,
+		Version:     "1.0",
+		Severity:    checks.WarningSeverity,
+		Func:        checks.HasVerifiedProvenance,
+	},
+}
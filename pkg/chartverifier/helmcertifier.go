@@ -17,12 +17,17 @@
 package chartverifier
 
 import (
+	"github.com/spf13/viper"
+
 	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
 )
 
 type CertifierBuilder interface {
 	SetRegistry(registry checks.Registry) CertifierBuilder
 	SetChecks(checks []string) CertifierBuilder
+	SetConfig(config *viper.Viper) CertifierBuilder
+	SetFailOn(severity checks.Severity) CertifierBuilder
+	SetProfile(profile *Profile) CertifierBuilder
 	Build() (Certifier, error)
 }
 
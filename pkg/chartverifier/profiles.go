@@ -0,0 +1,292 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chartverifier
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+
+	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+)
+
+// profileVersionSeparator separates the name of a profile from its version, as in partner@1.0.
+const profileVersionSeparator = "@"
+
+// ProfileCheck is a check run by a profile.
+type ProfileCheck struct {
+	// Name of the check in the registry.
+	Name string
+	// Version of the check the profile has been released with; the profile can't run other versions of the check,
+	// whose behavior might differ.
+	Version string
+	// Mandatory checks fail the certificate, being recorded as errors; the others are advisory, recorded as warnings.
+	Mandatory bool
+	// Config holds the parameters of the check; the config file can still override them.
+	Config map[string]interface{}
+}
+
+// Severity returns the severity the results of the check are recorded with.
+func (c ProfileCheck) Severity() checks.Severity {
+	if c.Mandatory {
+		return checks.ErrorSeverity
+	}
+	return checks.WarningSeverity
+}
+
+// Profile is a named and versioned set of checks. A released profile version never changes, so certificates
+// produced with the same profile version remain comparable across tool upgrades; changes go into a new version.
+type Profile struct {
+	Name    string
+	Version string
+	Checks  []ProfileCheck
+}
+
+// Check returns the given check of the profile.
+func (p *Profile) Check(name string) (ProfileCheck, bool) {
+	for _, c := range p.Checks {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return ProfileCheck{}, false
+}
+
+// CheckNames returns the names of the checks of the profile.
+func (p *Profile) CheckNames() []string {
+	names := make([]string, 0, len(p.Checks))
+	for _, c := range p.Checks {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// checkVersions verifies the given checks of the registry have the versions the profile has been released with.
+func (p *Profile) checkVersions(registry checks.Registry, names []string) error {
+	for _, name := range names {
+		pc, ok := p.Check(name)
+		if !ok {
+			continue
+		}
+		check, ok := registry.Get(name)
+		if !ok {
+			continue
+		}
+		if check.Version != pc.Version {
+			return errors.Errorf("profile %s@%s requires version %q of check %q, but version %q is available",
+				p.Name, p.Version, pc.Version, name, check.Version)
+		}
+	}
+	return nil
+}
+
+// copy returns a copy of the profile that can be changed without changing the profile.
+func (p *Profile) copy() *Profile {
+	cp := *p
+	cp.Checks = make([]ProfileCheck, 0, len(p.Checks))
+	for _, c := range p.Checks {
+		if c.Config != nil {
+			c.Config = copyConfigValue(c.Config).(map[string]interface{})
+		}
+		cp.Checks = append(cp.Checks, c)
+	}
+	return &cp
+}
+
+// copyConfigValue returns a deep copy of the given check parameter.
+func copyConfigValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = copyConfigValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = copyConfigValue(e)
+		}
+		return l
+	case []string:
+		return append([]string{}, v...)
+	default:
+		return v
+	}
+}
+
+// mandatory and advisory build the checks of the default profiles.
+func mandatory(name, version string, config map[string]interface{}) ProfileCheck {
+	return ProfileCheck{Name: name, Version: version, Mandatory: true, Config: config}
+}
+
+func advisory(name, version string, config map[string]interface{}) ProfileCheck {
+	return ProfileCheck{Name: name, Version: version, Config: config}
+}
+
+// defaultProfiles are the profiles available by default, in every released version.
+var defaultProfiles = []Profile{
+	{
+		Name:    "partner",
+		Version: "1.0",
+		Checks: []ProfileCheck{
+			mandatory("is-helm-v3", "1.0", nil),
+			mandatory("has-readme", "1.0", nil),
+			mandatory("contains-test", "1.0", nil),
+			mandatory("has-minkubeversion", "1.0", nil),
+			mandatory("contains-values", "1.0", nil),
+			mandatory("contains-values-schema", "1.0", nil),
+			mandatory("values-are-valid-against-schema", "1.0", nil),
+			mandatory("not-contains-crds", "1.0", nil),
+			mandatory("helm-lint", "1.0", nil),
+			mandatory("is-commercial-chart", "1.0", nil),
+			mandatory("not-contains-infra-plugins-and-drivers", "1.0", nil),
+			mandatory("can-be-installed-without-cluster-admin-privileges", "1.0", nil),
+			mandatory("images-are-from-allowed-registries", "1.0", nil),
+			mandatory("is-restricted-scc-compatible", "1.0", nil),
+			mandatory("has-complete-metadata", "1.0", nil),
+			mandatory("dependencies-are-locked-and-vendored", "1.0", nil),
+			advisory("readme-contains-values-schema", "1.0", nil),
+			advisory("keywords-are-openshift-categories", "1.0", nil),
+			advisory("can-be-installed-without-manual-prerequisites", "1.0", nil),
+			advisory("images-are-pinned-by-digest", "1.0", nil),
+			advisory("containers-set-resource-requests-and-limits", "1.0", nil),
+			advisory("pods-meet-pod-security-standards", "1.0", map[string]interface{}{
+				checks.PodSecurityLevelConfigKey: string(checks.PodSecurityBaseline),
+			}),
+			advisory("apis-are-available-in-kubeversion-range", "1.0", nil),
+			advisory("containers-have-probes", "1.0", nil),
+			advisory("has-verified-provenance", "1.0", nil),
+		},
+	},
+	{
+		Name:    "community",
+		Version: "1.0",
+		Checks: []ProfileCheck{
+			mandatory("is-helm-v3", "1.0", nil),
+			mandatory("has-readme", "1.0", nil),
+			mandatory("has-minkubeversion", "1.0", nil),
+			mandatory("contains-values", "1.0", nil),
+			mandatory("helm-lint", "1.0", nil),
+			mandatory("has-complete-metadata", "1.0", map[string]interface{}{
+				checks.RequiredAnnotationsConfigKey: []string{checks.NameAnnotation},
+			}),
+			advisory("contains-test", "1.0", nil),
+			advisory("contains-values-schema", "1.0", nil),
+			advisory("values-are-valid-against-schema", "1.0", nil),
+			advisory("readme-contains-values-schema", "1.0", nil),
+			advisory("not-contains-crds", "1.0", nil),
+			advisory("can-be-installed-without-cluster-admin-privileges", "1.0", nil),
+			advisory("can-be-installed-without-manual-prerequisites", "1.0", nil),
+			advisory("images-are-pinned-by-digest", "1.0", nil),
+			advisory("containers-set-resource-requests-and-limits", "1.0", nil),
+			advisory("pods-meet-pod-security-standards", "1.0", map[string]interface{}{
+				checks.PodSecurityLevelConfigKey: string(checks.PodSecurityBaseline),
+			}),
+			advisory("apis-are-available-in-kubeversion-range", "1.0", nil),
+			advisory("dependencies-are-locked-and-vendored", "1.0", nil),
+			advisory("containers-have-probes", "1.0", nil),
+		},
+	},
+	{
+		Name:    "redhat",
+		Version: "1.0",
+		Checks: []ProfileCheck{
+			mandatory("is-helm-v3", "1.0", nil),
+			mandatory("has-readme", "1.0", nil),
+			mandatory("readme-contains-values-schema", "1.0", nil),
+			mandatory("contains-test", "1.0", nil),
+			mandatory("has-minkubeversion", "1.0", nil),
+			mandatory("contains-values", "1.0", nil),
+			mandatory("contains-values-schema", "1.0", nil),
+			mandatory("values-are-valid-against-schema", "1.0", nil),
+			mandatory("not-contains-crds", "1.0", nil),
+			mandatory("helm-lint", "1.0", map[string]interface{}{
+				checks.HelmLintStrictConfigKey: true,
+			}),
+			mandatory("keywords-are-openshift-categories", "1.0", nil),
+			mandatory("is-commercial-chart", "1.0", nil),
+			mandatory("not-contains-infra-plugins-and-drivers", "1.0", nil),
+			mandatory("can-be-installed-without-cluster-admin-privileges", "1.0", nil),
+			mandatory("can-be-installed-without-manual-prerequisites", "1.0", nil),
+			mandatory("images-are-pinned-by-digest", "1.0", nil),
+			mandatory("images-are-from-allowed-registries", "1.0", map[string]interface{}{
+				checks.AllowedRegistriesConfigKey: []string{"registry.redhat.io", "registry.access.redhat.com"},
+			}),
+			mandatory("containers-set-resource-requests-and-limits", "1.0", nil),
+			mandatory("pods-meet-pod-security-standards", "1.0", map[string]interface{}{
+				checks.PodSecurityLevelConfigKey: string(checks.PodSecurityRestricted),
+			}),
+			mandatory("is-restricted-scc-compatible", "1.0", nil),
+			mandatory("apis-are-available-in-kubeversion-range", "1.0", nil),
+			mandatory("has-complete-metadata", "1.0", nil),
+			mandatory("dependencies-are-locked-and-vendored", "1.0", nil),
+			mandatory("containers-have-probes", "1.0", nil),
+			mandatory("has-verified-provenance", "1.0", nil),
+		},
+	},
+}
+
+// DefaultProfiles returns copies of the profiles available by default, in every released version.
+func DefaultProfiles() []Profile {
+	profiles := make([]Profile, 0, len(defaultProfiles))
+	for i := range defaultProfiles {
+		profiles = append(profiles, *defaultProfiles[i].copy())
+	}
+	return profiles
+}
+
+// GetProfile returns a copy of the profile of the given name, optionally followed by '@' and the version; the latest
+// version of the profile is returned when none is given.
+func GetProfile(ref string) (*Profile, error) {
+	name, version := ref, ""
+	if i := strings.Index(ref, profileVersionSeparator); i >= 0 {
+		name, version = ref[:i], ref[i+len(profileVersionSeparator):]
+	}
+
+	var (
+		found  *Profile
+		latest *semver.Version
+	)
+	for i, p := range defaultProfiles {
+		if p.Name != name {
+			continue
+		}
+		if version != "" {
+			if p.Version == version {
+				return defaultProfiles[i].copy(), nil
+			}
+			continue
+		}
+		v, err := semver.NewVersion(p.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid version of profile %q", p.Name)
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			found, latest = &defaultProfiles[i], v
+		}
+	}
+
+	if found == nil {
+		if version != "" {
+			return nil, errors.Errorf("profile %q has no version %q", name, version)
+		}
+		return nil, errors.Errorf("profile %q is unknown", name)
+	}
+	return found.copy(), nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chartverifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+)
+
+func TestGetProfile(t *testing.T) {
+	t.Run("Should return the latest version when none is given", func(t *testing.T) {
+		p, err := GetProfile("partner")
+		require.NoError(t, err)
+		require.Equal(t, "partner", p.Name)
+		require.Equal(t, "1.0", p.Version)
+	})
+
+	t.Run("Should return the given version", func(t *testing.T) {
+		p, err := GetProfile("community@1.0")
+		require.NoError(t, err)
+		require.Equal(t, "community", p.Name)
+		require.Equal(t, "1.0", p.Version)
+	})
+
+	t.Run("Should return a copy of the profile", func(t *testing.T) {
+		p, err := GetProfile("redhat@1.0")
+		require.NoError(t, err)
+		registries := p.Checks[16].Config[checks.AllowedRegistriesConfigKey].([]string)
+		registries[0] = "quay.io"
+		p.Checks[0].Mandatory = false
+		p.Checks = p.Checks[:1]
+
+		p, err = GetProfile("redhat@1.0")
+		require.NoError(t, err)
+		require.Len(t, p.Checks, 25)
+		require.True(t, p.Checks[0].Mandatory)
+		require.Equal(t, "images-are-from-allowed-registries", p.Checks[16].Name)
+		require.Equal(t, []string{"registry.redhat.io", "registry.access.redhat.com"},
+			p.Checks[16].Config[checks.AllowedRegistriesConfigKey])
+
+		DefaultProfiles()[0].Checks[0].Name = "changed"
+		require.Equal(t, "is-helm-v3", DefaultProfiles()[0].Checks[0].Name)
+	})
+
+	t.Run("Should fail when the profile is unknown", func(t *testing.T) {
+		_, err := GetProfile("unknown")
+		require.Error(t, err)
+	})
+
+	t.Run("Should fail when the profile version is unknown", func(t *testing.T) {
+		_, err := GetProfile("redhat@0.1")
+		require.Error(t, err)
+	})
+}
+
+func TestDefaultProfiles(t *testing.T) {
+	for _, p := range DefaultProfiles() {
+		seen := map[string]bool{}
+		for _, c := range p.Checks {
+			check, ok := DefaultRegistry().Get(c.Name)
+			require.True(t, ok, "%s@%s: check %q is unknown", p.Name, p.Version, c.Name)
+			require.Equal(t, check.Version, c.Version, "%s@%s: check %q version", p.Name, p.Version, c.Name)
+			require.False(t, seen[c.Name], "%s@%s: check %q is repeated", p.Name, p.Version, c.Name)
+			seen[c.Name] = true
+		}
+	}
+}
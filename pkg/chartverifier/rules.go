@@ -0,0 +1,48 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chartverifier
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+)
+
+// RegistryWithRules returns a registry holding the checks of the given registry along with the rules declared in the
+// given rule files; the given registry is left untouched. Rules can't replace existing checks.
+func RegistryWithRules(registry checks.Registry, filenames []string) (checks.Registry, error) {
+	withRules := checks.NewRegistry()
+	for _, name := range registry.AllChecks() {
+		check, _ := registry.Get(name)
+		withRules.Add(check)
+	}
+
+	for _, filename := range filenames {
+		rules, err := checks.LoadRules(filename)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if _, ok := withRules.Get(rule.Name); ok {
+				return nil, errors.Errorf("rule file %s: rule %q is already a check", filename, rule.Name)
+			}
+			withRules.Add(rule.Check())
+		}
+	}
+
+	return withRules, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2021 Red Hat
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chartverifier
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/redhat-certification/chart-verifier/pkg/chartverifier/checks"
+)
+
+func TestRegistryWithRules(t *testing.T) {
+	t.Run("Should add the rules to a copy of the registry", func(t *testing.T) {
+		registry, err := RegistryWithRules(DefaultRegistry(), []string{"checks/rules.yaml"})
+		require.NoError(t, err)
+		require.Len(t, registry.AllChecks(), len(defaultChecks)+9)
+
+		check, ok := registry.Get("deployments-have-team-label")
+		require.True(t, ok)
+		require.Equal(t, checks.CustomCategory, check.Category)
+
+		_, ok = DefaultRegistry().Get("deployments-have-team-label")
+		require.False(t, ok)
+	})
+
+	t.Run("Should fail when a rule is named after an existing check", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "rules.yaml")
+		content := "rules:\n- name: has-readme\n  target: {source: chart}\n  assert: {path: icon}\n  message: m\n"
+		require.NoError(t, ioutil.WriteFile(filename, []byte(content), 0644))
+
+		_, err := RegistryWithRules(DefaultRegistry(), []string{filename})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already a check")
+	})
+
+	t.Run("Should fail when the same rule is declared in two files", func(t *testing.T) {
+		_, err := RegistryWithRules(DefaultRegistry(), []string{"checks/rules.yaml", "checks/rules.yaml"})
+		require.Error(t, err)
+	})
+}